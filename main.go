@@ -2,6 +2,9 @@ package main
 
 import (
 	"fmt"
+	"math"
+	"os"
+	"time"
 )
 
 // NMAX defines the maximum number of users and comments that can be stored in the application.
@@ -11,21 +14,554 @@ const NMAX int = 255
 // User represents a user account in the system.
 // Each user has a unique identifier, username, and password for authentication.
 type User struct {
-	id       int    // Unique identifier for the user
-	username string // Username for login and display purposes
-	password string // Password for authentication
+	id           int       // Unique identifier for the user
+	username     string    // Username for login and display purposes
+	password     string    // Password for authentication
+	status       string    // Account state: "active", "suspended", or "banned"
+	dibuat       time.Time // The time the account was created
+	ditangguhkan time.Time // End of the suspension when status is "suspended"
+	alasanSanksi string    // Reason of the current suspension or ban
 }
 
+// Sanction records a single suspension, ban, or lifting of a sanction on a user account.
+type Sanction struct {
+	id     int       // Unique identifier for the sanction
+	userId int       // Identifier of the sanctioned user
+	jenis  string    // Kind of action: "suspend", "ban", or "lift"
+	alasan string    // Reason given by the administrator
+	sampai time.Time // End of the suspension, zero for bans and lifts
+	dibuat time.Time // The time the sanction was recorded
+}
+
+// sanctions is an array storing the most recent sanctions.
+// When it is full the oldest entry is discarded; the current state is kept on the User itself.
+var sanctions [NMAX]Sanction
+
+// nSanction tracks the current number of sanctions stored in the sanctions array.
+var nSanction int = 0
+
+// idSanction is a counter for generating unique sanction IDs, starting from 1.
+var idSanction int = 1
+
+// LoginGuard tracks the failed login attempts and lockout of a single account or unknown username.
+type LoginGuard struct {
+	userId         int       // ID of the user account, 0 when the guard belongs to a name
+	username       string    // adminLoginName or the unknown username the attempts were made for, empty for user accounts
+	gagal          int       // Number of consecutive failed attempts since the last lockout
+	penguncian     int       // Number of lockouts since the last successful login
+	terkunciSampai time.Time // End of the current lockout
+}
+
+// LoginRecord records a single login attempt.
+type LoginRecord struct {
+	username   string    // The username the attempt was made for
+	berhasil   bool      // Whether the login succeeded
+	keterangan string    // Short description of the outcome
+	waktu      time.Time // The time of the attempt
+}
+
+// adminLoginName is the name under which attempts on the administrator password are tracked.
+// It contains characters that are not allowed in usernames so it cannot collide with a user.
+const adminLoginName string = "(admin)"
+
+// loginGuards is an array storing the failed attempt counters per account, keyed by user ID so that
+// a lockout survives a rename. Only existing users and the administrator get an entry, so there is
+// room for every account.
+var loginGuards [NMAX + 1]LoginGuard
+
+// nLoginGuard tracks the current number of entries stored in the loginGuards array.
+var nLoginGuard int = 0
+
+// unknownLoginGuards stores the failed attempt counters of usernames that do not belong to an account,
+// so unknown and existing usernames lock the same way. They are kept apart from loginGuards and
+// reused when full, so they can never take the place of an account.
+var unknownLoginGuards [NMAX]LoginGuard
+
+// nUnknownLoginGuard tracks the current number of entries stored in the unknownLoginGuards array.
+var nUnknownLoginGuard int = 0
+
+// loginLog is an array storing the most recent login attempts.
+// When it is full the oldest entry is discarded.
+var loginLog [NMAX]LoginRecord
+
+// nLoginLog tracks the current number of entries stored in the loginLog array.
+var nLoginLog int = 0
+
+// batasGagalLogin is the number of consecutive failed attempts that locks a username.
+var batasGagalLogin int = 3
+
+// durasiKunciDasar is the length of the first lockout. Every following lockout before a
+// successful login doubles in length, up to durasiKunciMaks.
+var durasiKunciDasar time.Duration = 30 * time.Second
+
+// durasiKunciMaks is the maximum length of a single lockout.
+var durasiKunciMaks time.Duration = 24 * time.Hour
+
+// Session represents a logged-in user. The user is looked up again from the store on every
+// action, so changes made by an administrator take effect immediately.
+type Session struct {
+	id            int       // Unique identifier for the session
+	userId        int       // ID of the logged-in user
+	dibuat        time.Time // The time the session was started
+	terakhirAktif time.Time // The time of the last validated action
+	kedaluwarsa   time.Time // The time after which the session ends regardless of activity
+}
+
+// sessions is an array storing the active sessions, sorted by ID.
+var sessions [NMAX]Session
+
+// nSession tracks the current number of sessions stored in the sessions array.
+var nSession int = 0
+
+// idSession is a counter for generating unique session IDs, starting from 1.
+var idSession int = 1
+
+// durasiSesi is the maximum lifetime of a session.
+var durasiSesi time.Duration = 8 * time.Hour
+
+// batasIdle is how long a session may stay without any action before it ends.
+var batasIdle time.Duration = 15 * time.Minute
+
+// userDihapusId is the user ID given to comments kept after their author deleted the account.
+const userDihapusId = -1
+
+// UserCommentStats summarizes the comments written by a single user.
+type UserCommentStats struct {
+	total       int    // Number of comments
+	perKategori [3]int // Number of comments per category, in kategoriList order
+	perStatus   [4]int // Number of comments per status, in statusList order
+	toksik      int    // Number of toxic comments
+	duplikat    int    // Number of comments marked as duplicates
+}
+
+// PasswordPolicy describes the rules a new password must satisfy.
+type PasswordPolicy struct {
+	panjangMin        int  // Minimum number of characters
+	wajibHurufKecil   bool // Whether a lowercase letter is required
+	wajibHurufBesar   bool // Whether an uppercase letter is required
+	wajibAngka        bool // Whether a digit is required
+	wajibSimbol       bool // Whether a character other than a letter or digit is required
+	tolakSamaUsername bool // Whether a password equal to the username is rejected
+}
+
+// kebijakanPassword is the password policy enforced when users are created or changed.
+var kebijakanPassword = PasswordPolicy{
+	panjangMin:        8,
+	wajibHurufKecil:   true,
+	wajibHurufBesar:   true,
+	wajibAngka:        true,
+	wajibSimbol:       false,
+	tolakSamaUsername: true,
+}
+
+// panjangUsernameMin is the minimum number of characters in a username.
+var panjangUsernameMin int = 3
+
+// panjangUsernameMaks is the maximum number of characters in a username.
+var panjangUsernameMaks int = 20
+
 // Comment represents a sentiment comment in the system.
 // Each comment has a unique identifier, the user ID of the author,
 // the comment text, and a category classification.
 type Comment struct {
-	id       int    // Unique identifier for the comment
-	userId   int    // Identifier of the user who created the comment
-	komentar string // The actual comment text content
-	kategori string // The sentiment category or classification of the comment
+	id                 int                     // Unique identifier for the comment
+	userId             int                     // Identifier of the user who created the comment
+	komentar           string                  // The actual comment text content
+	kategori           string                  // The sentiment category predicted by the automatic classifier
+	keyakinan          float64                 // Confidence of the predicted category, between 0 and 1
+	kategoriKonfirmasi string                  // The category confirmed by a moderator, empty if not yet reviewed
+	aspek              [NASPEK]AspectSentiment // Sentiment of each aspect mentioned in the comment
+	nAspek             int                     // Number of entries stored in aspek
+	skorToksisitas     float64                 // Sum of the severity of the abusive words in the comment
+	toksik             bool                    // Whether the toxicity score reached ambangToksisitas
+	dibuat             time.Time               // The time the comment was created
+	parentId           int                     // Identifier of the comment this one replies to, 0 for a top-level comment
+	sumber             CommentSource           // Where the comment was posted, empty for comments written in the application
+	duplikatDari       int                     // Identifier of the comment this one duplicates, 0 if it is not flagged
+	jumlahGabungan     int                     // Number of duplicate comments merged into this one
+	status             string                  // Moderation status, one of statusList
+	alasanStatus       string                  // Reason given for the latest status change
+	statusOleh         string                  // Who made the latest status change
+	statusDiubah       time.Time               // The time of the latest status change
+	shingles           []string                // Distinct shingles of the normalized text in ascending order
+}
+
+// CommentSource describes where a comment was originally posted.
+type CommentSource struct {
+	platform    string // Name of the platform, e.g. instagram or twitter
+	postId      string // Identifier of the post or thread the comment belongs to
+	idEksternal string // Identifier of the comment on the platform
+	handle      string // Handle of the author on the platform
+}
+
+// CommentFilter holds the conditions a comment must meet to appear in a listing.
+// Empty fields match every comment.
+type CommentFilter struct {
+	status     string        // Moderation status, one of statusList
+	kategori   string        // Effective sentiment category, one of kategoriList
+	penulisId  int           // ID of the author's account, 0 for every author
+	dari       time.Time     // First day of the creation date range
+	sampai     time.Time     // Last day of the creation date range, inclusive
+	panjangMin int           // Minimum length of the comment in characters, 0 for no minimum
+	panjangMax int           // Maximum length of the comment in characters, 0 for no maximum
+	tanda      [4]bool       // Moderation flags the comment must carry, indexed like tandaList
+	sumber     CommentSource // Source fields, compared case-insensitively
+}
+
+// NSORTKEY is the maximum number of keys in a sort, the first one plus the secondary keys.
+const NSORTKEY int = 3
+
+// SortSpec describes how a listing is sorted: the keys in order of priority, the direction of each key,
+// and the sorting algorithm. Entries that are equal on every key are ordered by ID.
+type SortSpec struct {
+	kunci     [NSORTKEY]string // Sort keys, taken from kunciSortKomentar or kunciSortUser
+	menurun   [NSORTKEY]bool   // Whether each key is sorted in descending order
+	nKunci    int              // Number of keys stored in kunci
+	algoritma string           // "selection" or "insertion"
+}
+
+// SortStats counts the work done by a sort so the algorithms can be compared.
+type SortStats struct {
+	perbandingan int // Number of comparisons between two entries
+	pertukaran   int // Number of swaps for selection sort, or shifted entries for insertion sort
+}
+
+// kunciSortKomentar lists the keys comments can be sorted on.
+var kunciSortKomentar = [5]string{"id", "penulis", "tanggal", "sentimen", "panjang"}
+
+// kunciSortUser lists the keys users can be sorted on.
+var kunciSortUser = [4]string{"id", "username", "tanggal", "jumlah komentar"}
+
+// algoritmaSort lists the sorting algorithms that can be chosen.
+var algoritmaSort = [2]string{"selection", "insertion"}
+
+// NBENCH is the maximum number of result rows of a benchmark run.
+const NBENCH int = 64
+
+// NBENCHSIZE is the maximum number of data set sizes in a benchmark run.
+const NBENCHSIZE int = 8
+
+// BenchmarkResult holds the measurements of one algorithm on one synthetic data set.
+// The operation counts are totals over all repetitions.
+type BenchmarkResult struct {
+	algoritma    string        // Name of the measured algorithm
+	fungsi       string        // Function that implements it
+	ukuran       int           // Number of comments in the data set
+	ulangan      int           // Number of repetitions
+	durasi       time.Duration // Total time of all repetitions
+	perbandingan int           // Comparisons, or comments examined for sequential search
+	pertukaran   int           // Swaps or shifted entries, 0 for the searches
+}
+
+// kataSintetis is the vocabulary synthetic benchmark comments are built from.
+var kataSintetis = [16]string{"produk", "bagus", "jelek", "pengiriman", "cepat", "lambat", "harga", "murah", "mahal", "pelayanan", "ramah", "kecewa", "puas", "barang", "sesuai", "rusak"}
+
+// ulanganBenchmarkDefault is the number of repetitions of each algorithm when none is given.
+const ulanganBenchmarkDefault int = 100
+
+// Pagination holds the page shown by a listing and the number of entries per page.
+type Pagination struct {
+	halaman int // The current page, starting at 1
+	ukuran  int // Number of entries shown per page
+}
+
+// ukuranHalamanDefault is the number of entries per page when a listing is opened.
+const ukuranHalamanDefault int = 10
+
+// SavedFilter is a comment filter stored under a name so its owner can load it again later.
+type SavedFilter struct {
+	ownerId int           // ID of the user who saved the filter, 0 for the administrator
+	nama    string        // Name chosen by the owner, unique per owner regardless of case
+	filter  CommentFilter // The stored conditions
+}
+
+// NFILTER is the maximum number of saved filters per owner.
+const NFILTER int = 16
+
+// tandaList lists the moderation flags a listing can be filtered on: toxic, flagged as duplicate,
+// has merged duplicates, and waiting in the review queue.
+var tandaList = [4]string{"toksik", "duplikat", "digabung", "perlu ditinjau"}
+
+// savedFilters holds the filters saved by every owner, in the order they were saved.
+var savedFilters [NMAX]SavedFilter
+
+// nSavedFilter is the number of entries stored in savedFilters.
+var nSavedFilter int = 0
+
+// Posting records how often a term occurs in one comment.
+type Posting struct {
+	commentId int // ID of the comment containing the term
+	tf        int // Number of times the term occurs in the comment
+}
+
+// IndexTerm is an entry of the inverted index: a term with the comments containing it,
+// sorted by comment ID.
+type IndexTerm struct {
+	term     string        // The token as produced by tokenize
+	postings [NMAX]Posting // The comments containing the term
+	nPosting int           // Number of postings, which is the document frequency of the term
+}
+
+// IndexDoc records the number of tokens of an indexed comment.
+type IndexDoc struct {
+	commentId int // ID of the indexed comment
+	panjang   int // Number of tokens in the comment
+}
+
+// indexTerms stores the terms of the inverted index in the order they were first seen.
+var indexTerms [NVOCAB]IndexTerm
+
+// nIndexTerm tracks the current number of terms stored in the indexTerms array.
+var nIndexTerm int = 0
+
+// indexUrut holds the positions of the terms in indexTerms sorted alphabetically by term,
+// so a term can be found with binary search without moving the large IndexTerm entries.
+var indexUrut [NVOCAB]int
+
+// indexDocs stores the length of every indexed comment, sorted by comment ID.
+var indexDocs [NMAX]IndexDoc
+
+// nIndexDoc tracks the current number of comments stored in the indexDocs array.
+var nIndexDoc int = 0
+
+// totalPanjangDoc is the sum of the lengths of all indexed comments, used for the average length in BM25.
+var totalPanjangDoc int = 0
+
+// bm25K1 controls how quickly repeated occurrences of a term stop increasing the BM25 score.
+const bm25K1 float64 = 1.2
+
+// bm25B controls how strongly the BM25 score is normalized by comment length.
+const bm25B float64 = 0.75
+
+// NREGEX defines the maximum number of states a compiled regular expression may have.
+const NREGEX int = 512
+
+// NRANGE defines the maximum number of ranges in one character class of a regular expression.
+const NRANGE int = 32
+
+// panjangRegexMaks is the maximum number of characters in a regular expression pattern.
+const panjangRegexMaks int = 256
+
+// pengulanganRegexMaks is the largest count allowed in a {m,n} repetition.
+const pengulanganRegexMaks int = 100
+
+// teksRegexMaks is the maximum number of characters of a text that is searched with a regular
+// expression. Longer texts are only searched in their first teksRegexMaks characters.
+const teksRegexMaks int = 2000
+
+// langkahRegexMaks is the maximum number of characters read while counting the matches in one text.
+// Some patterns need to read ahead past the end of every match; when the limit is reached the
+// count stops, so a single text can never take long to search.
+const langkahRegexMaks int = 50000
+
+// RegexState is a state of the automaton compiled from a regular expression.
+type RegexState struct {
+	jenis  string          // One of "rune", "any", "class", "eps", "split", "bol", "eol", "match"
+	r      rune            // The character matched by a "rune" state
+	ranges [NRANGE][2]rune // The inclusive ranges matched by a "class" state
+	nRange int             // Number of ranges stored in ranges
+	negasi bool            // Whether a "class" state matches the characters outside its ranges
+	out    int             // The next state, -1 when not yet connected
+	out2   int             // The second next state of a "split" state
+}
+
+// Regex is a regular expression compiled into a nondeterministic automaton.
+type Regex struct {
+	states         [NREGEX]RegexState // The states of the automaton
+	nState         int                // Number of states stored in states
+	mulai          int                // Index of the start state
+	abaikanKapital bool               // Whether letters match regardless of case, set by a leading (?i)
+}
+
+// regexFrag is a part of an automaton under construction. It has a single entry state and
+// a single exit state of type "eps" whose out is connected by the caller.
+type regexFrag struct {
+	mulai int // Index of the entry state
+	akhir int // Index of the exit state
+}
+
+// regexParser holds the state of the recursive descent parser for regular expressions.
+type regexParser struct {
+	pola []rune // The pattern being compiled
+	pos  int    // Index of the next character to read
+	re   *Regex // The automaton being built
+}
+
+// SearchMatcher holds a prepared search in one of the search modes. The same matcher both selects
+// the results and finds the spans that are highlighted in the listing, so both always agree.
+type SearchMatcher struct {
+	mode  string         // One of "kata", "query", "fuzzy", "fuzzyNama", "relevansi", "regex"
+	teks  string         // The search as entered
+	query Query          // The parsed query in "query" mode
+	regex Regex          // The compiled pattern in "regex" mode
+	kata  [NTOKEN]string // The search words in "fuzzy" and "relevansi" mode
+	nKata int            // Number of words stored in kata
+}
+
+// panjangSnippetMaks is the number of characters above which a search result is shortened
+// to the text around its matches.
+const panjangSnippetMaks int = 100
+
+// konteksSnippet is the number of characters shown on each side of a match in a shortened result.
+const konteksSnippet int = 25
+
+// warnaAktif reports whether matches are highlighted with ANSI colours. When the terminal does not
+// support colours the matches are surrounded with brackets instead.
+var warnaAktif bool = terminalSupportsColor()
+
+// jarakFuzzyMaks is the maximum edit distance between a search word and a word of the text
+// for the word to count as a match in fuzzy search.
+var jarakFuzzyMaks int = 2
+
+// jarakFuzzyBatas is the largest value jarakFuzzyMaks may be set to. Larger distances match
+// almost any short word.
+const jarakFuzzyBatas int = 5
+
+// NQUERY defines the maximum number of tokens and syntax tree nodes in a search query.
+const NQUERY int = 64
+
+// QueryToken is a single token of a search query.
+type QueryToken struct {
+	jenis  string // One of "kata", "frasa", "(", ")", "AND", "OR", "NOT"
+	teks   string // The text of a word or phrase
+	posisi int    // Position of the token in the query, starting from 1
+}
+
+// QueryNode is a node of the syntax tree of a search query.
+// Operator nodes refer to their operands by index in Query.nodes.
+type QueryNode struct {
+	jenis string // One of "and", "or", "not", "kata", "frasa", "field", "len"
+	kiri  int    // Index of the left operand, or the only operand of "not"
+	kanan int    // Index of the right operand
+	field string // Field name of a "field" node
+	op    string // Comparison operator of a "len" node
+	nilai string // Lowercase word, phrase, or field value
+	angka int    // Number compared against by a "len" node
+}
+
+// Query is a parsed search query.
+type Query struct {
+	nodes [NQUERY]QueryNode // The nodes of the syntax tree
+	nNode int               // Number of nodes stored in nodes
+	akar  int               // Index of the root node
+}
+
+// queryParser holds the state of the recursive descent parser for search queries.
+type queryParser struct {
+	tokens [NQUERY]QueryToken // The tokens of the query
+	nToken int                // Number of tokens
+	pos    int                // Index of the next token to read
+	query  *Query             // The query being built
+}
+
+// queryFields lists the field names that can be used as field:value in a search query.
+var queryFields = [6]string{"kategori", "user", "status", "platform", "post", "handle"}
+
+// SourceStats holds the sentiment counts of the comments from one platform and post.
+type SourceStats struct {
+	platform    string // Name of the platform
+	postId      string // Identifier of the post
+	perKategori [3]int // Number of comments per category, in kategoriList order
+	toksik      int    // Number of toxic comments
+}
+
+// ThreadSentiment summarizes the sentiment of a comment together with all of its replies.
+type ThreadSentiment struct {
+	jumlah      int     // Number of comments in the thread, including the root
+	skor        float64 // Average lexicon score of the comments in the thread
+	kategori    string  // Category of the average score
+	perKategori [3]int  // Number of comments per category, in kategoriList order
+}
+
+// statusList lists the moderation statuses a comment can have.
+// Only approved comments are shown in user-facing views.
+var statusList = [4]string{"pending", "approved", "hidden", "removed"}
+
+// transisiStatus defines the allowed moderation status changes, indexed as [from][to]
+// using the order of statusList. A removed comment can only be sent back to pending.
+var transisiStatus = [4][4]bool{
+	{false, true, true, true},
+	{true, false, true, true},
+	{true, true, false, true},
+	{true, false, false, false},
+}
+
+// NSHINGLE defines the maximum number of shingles taken from a single comment text.
+const NSHINGLE int = 512
+
+// panjangShingle is the number of runes in each shingle used for near-duplicate detection.
+const panjangShingle int = 3
+
+// ambangKemiripan is the minimum Jaccard similarity for two comments to be considered duplicates.
+var ambangKemiripan float64 = 0.8
+
+// duplicateClusters caches the cluster numbers computed by GetDuplicateClusters.
+var duplicateClusters [NMAX]int
+
+// nDuplicateCluster caches the number of clusters computed by GetDuplicateClusters.
+var nDuplicateCluster int = 0
+
+// clusterValid is false when the comments or ambangKemiripan changed since the clusters were last computed.
+var clusterValid bool = false
+
+// batasKomentar is the maximum number of comments a user may post within jendelaBatas.
+var batasKomentar int = 5
+
+// jendelaBatas is the time window used by the per-user rate limit.
+var jendelaBatas time.Duration = time.Minute
+
+// PostAttempt records a single comment posted by a user, used to enforce the rate limit.
+type PostAttempt struct {
+	userId int       // Identifier of the user who posted
+	waktu  time.Time // The time of the post
+}
+
+// riwayatPost is an array storing the most recent posts of all users.
+// When it is full the oldest entry is discarded.
+var riwayatPost [NMAX]PostAttempt
+
+// nRiwayatPost tracks the current number of entries stored in the riwayatPost array.
+var nRiwayatPost int = 0
+
+// NASPEK defines the maximum number of aspects that can be defined by administrators.
+const NASPEK int = 16
+
+// NKATAASPEK defines the maximum number of trigger keywords per aspect.
+const NKATAASPEK int = 16
+
+// aspekJendela is the number of tokens on each side of an aspect keyword whose sentiment
+// is attributed to that aspect.
+const aspekJendela int = 2
+
+// Aspect represents a topic of a comment, such as price or delivery, that is recognized
+// by its trigger keywords.
+type Aspect struct {
+	id        int                // Unique identifier for the aspect
+	nama      string             // Display name of the aspect
+	kataKunci [NKATAASPEK]string // Trigger keywords in lowercase
+	nKata     int                // Number of entries stored in kataKunci
+}
+
+// AspectSentiment holds the sentiment of a comment towards a single aspect.
+type AspectSentiment struct {
+	aspekId  int     // Identifier of the aspect
+	kategori string  // The sentiment category towards the aspect
+	skor     float64 // The lexicon score of the tokens around the aspect keywords
 }
 
+// aspects is an array storing all aspects defined by administrators.
+var aspects = [NASPEK]Aspect{
+	{1, "harga", [NKATAASPEK]string{"harga", "price", "mahal", "murah", "diskon", "ongkos", "biaya"}, 7},
+	{2, "pelayanan", [NKATAASPEK]string{"pelayanan", "layanan", "service", "admin", "cs", "penjual", "seller", "respon"}, 8},
+	{3, "pengiriman", [NKATAASPEK]string{"pengiriman", "kirim", "dikirim", "kurir", "delivery", "paket", "ongkir", "sampai"}, 8},
+}
+
+// nAspect tracks the current number of aspects stored in the aspects array.
+var nAspect int = 3
+
+// idAspect is a counter for generating unique aspect IDs.
+var idAspect int = 4
+
 // users is an array storing all registered user accounts.
 // The array has a fixed size determined by the NMAX constant.
 var users [NMAX]User
@@ -49,9 +585,168 @@ var idComment int = 1
 // passwordAdmin is the authentication credential for the administrator account.
 const passwordAdmin string = "admin123"
 
+// NTOKEN defines the maximum number of tokens taken from a single comment text.
+const NTOKEN int = 255
+
+// NVOCAB defines the maximum number of distinct words a trainable model can learn.
+const NVOCAB int = 2048
+
+// kategoriList lists the sentiment categories in the order used by matrices and reports.
+var kategoriList = [3]string{"positif", "netral", "negatif"}
+
+// ambangPositif is the minimum lexicon score for a comment to be classified as positive.
+const ambangPositif float64 = 0.5
+
+// ambangNegatif is the maximum lexicon score for a comment to be classified as negative.
+const ambangNegatif float64 = -0.5
+
+// ambangKeyakinan is the minimum confidence for a prediction to be trusted without review.
+// Unconfirmed comments below this value are placed in the review queue.
+var ambangKeyakinan float64 = 0.6
+
+// keyakinanTanpaKata is the confidence of a comment without any lexicon keyword. Such a comment is neutral
+// because it expresses no sentiment the lexicon knows, which is taken as reasonably reliable: it stays above
+// the default ambangKeyakinan, so comments without sentiment words do not flood the review queue.
+const keyakinanTanpaKata float64 = 0.75
+
+// SentimentWord represents a single keyword in the sentiment lexicon.
+// Positive weights indicate positive sentiment and negative weights indicate negative sentiment.
+type SentimentWord struct {
+	kata  string  // The keyword in lowercase
+	bobot float64 // The sentiment weight of the keyword
+}
+
+// ModifierWord represents an intensifier that scales the weight of the next sentiment keyword.
+type ModifierWord struct {
+	kata   string  // The modifier in lowercase
+	faktor float64 // The multiplier applied to the next keyword weight
+}
+
+// TokenExplanation records how the sentiment analyzer treated a single token.
+type TokenExplanation struct {
+	token      string  // The lowercase token taken from the comment text
+	sumber     string  // What the token matched: "leksikon", "emoji", "negasi", "intensifier", or "" for no match
+	bobot      float64 // The lexicon weight or intensifier multiplier of the token
+	faktor     float64 // The intensifier multiplier applied to a lexicon keyword
+	negated    bool    // Whether a negation flipped the weight of a lexicon keyword
+	kontribusi float64 // The amount added to the final score
+}
+
+// SentimentResult holds the outcome of analyzing a single comment text.
+type SentimentResult struct {
+	kategori  string                   // The predicted sentiment category
+	skor      float64                  // The accumulated lexicon score
+	keyakinan float64                  // Confidence of the predicted category, between 0 and 1
+	tokens    [NTOKEN]TokenExplanation // How each token of the comment was treated
+	nToken    int                      // Number of entries stored in tokens
+}
+
+// lexicon is the list of positive and negative keywords used by the sentiment analyzer.
+var lexicon = [NMAX]SentimentWord{
+	{"bagus", 1}, {"baik", 1}, {"suka", 1}, {"senang", 1}, {"mantap", 1.5}, {"keren", 1},
+	{"hebat", 1.5}, {"puas", 1}, {"cepat", 0.5}, {"ramah", 1}, {"murah", 0.5}, {"terbaik", 2},
+	{"rekomendasi", 1}, {"recommended", 1}, {"cinta", 1.5}, {"indah", 1}, {"lucu", 0.5}, {"membantu", 1},
+	{"good", 1}, {"great", 1.5}, {"love", 1.5}, {"nice", 1}, {"best", 2}, {"memuaskan", 1.5},
+	{"buruk", -1}, {"jelek", -1}, {"benci", -1.5}, {"kecewa", -1.5}, {"lambat", -0.5}, {"lemot", -1},
+	{"mahal", -0.5}, {"parah", -1}, {"rusak", -1}, {"bohong", -1.5}, {"penipu", -2}, {"sampah", -2},
+	{"marah", -1}, {"payah", -1}, {"kasar", -1}, {"mengecewakan", -1.5}, {"terburuk", -2}, {"gagal", -1},
+	{"bad", -1}, {"hate", -1.5}, {"worst", -2}, {"poor", -1}, {"scam", -2}, {"lama", -0.5},
+}
+
+// nLexicon tracks the number of entries stored in the lexicon array.
+var nLexicon int = countSentimentWords(lexicon)
+
+// emojiLexicon is the list of emoji and ASCII emoticons used by the sentiment analyzer.
+// Emoticons are stored in lowercase because they are matched against lowercased text.
+var emojiLexicon = [NMAX]SentimentWord{
+	{"😍", 2}, {"🥰", 2}, {"❤", 2}, {"😊", 1.5}, {"😁", 1.5}, {"😄", 1.5}, {"👍", 1.5}, {"👏", 1.5},
+	{"💯", 1.5}, {"😀", 1}, {"😂", 1}, {"🤣", 1}, {"🔥", 1}, {"🙏", 0.5}, {"😘", 1.5}, {"🤩", 2},
+	{"😡", -2}, {"🤬", -2}, {"🤮", -2}, {"😠", -1.5}, {"😭", -1.5}, {"👎", -1.5}, {"💩", -1.5}, {"💔", -1.5},
+	{"😢", -1}, {"😞", -1}, {"😒", -1}, {"🙄", -1}, {"😤", -1}, {"😔", -1}, {"😩", -1}, {"🤦", -1},
+	{":-)", 1}, {":)", 1}, {":-d", 1.5}, {":d", 1.5}, {";-)", 0.5}, {";)", 0.5}, {"<3", 1.5}, {":p", 0.5},
+	{"^_^", 1}, {"^^", 1}, {">:(", -2}, {":'(", -1.5}, {":-(", -1}, {":(", -1}, {"</3", -1.5}, {":-/", -0.5},
+	{":/", -0.5}, {"-_-", -0.5},
+}
+
+// nEmoji tracks the number of entries stored in the emojiLexicon array.
+var nEmoji int = countSentimentWords(emojiLexicon)
+
+// negationWords lists the words that flip the sentiment of the next keyword.
+var negationWords = [NMAX]string{"tidak", "tak", "bukan", "gak", "nggak", "ga", "enggak", "belum", "jangan", "kurang", "not", "no"}
+
+// nNegation tracks the number of entries stored in the negationWords array.
+var nNegation int = 12
+
+// intensifierWords lists the modifiers that strengthen or weaken the next keyword.
+var intensifierWords = [NMAX]ModifierWord{
+	{"sangat", 1.5}, {"amat", 1.5}, {"banget", 1.5}, {"sekali", 1.5}, {"paling", 2},
+	{"terlalu", 1.3}, {"agak", 0.5}, {"sedikit", 0.5}, {"very", 1.5}, {"really", 1.5},
+}
+
+// nIntensifier tracks the number of entries stored in the intensifierWords array.
+var nIntensifier int = 10
+
+// ToxicWord represents an abusive word recognized by the toxicity detector.
+type ToxicWord struct {
+	kata    string  // The abusive word in lowercase, without leetspeak
+	tingkat float64 // The severity added to the toxicity score of a comment
+}
+
+// toxicWords is the configurable list of profanity and insults used by the toxicity detector.
+var toxicWords = [NMAX]ToxicWord{
+	{"anjing", 1}, {"bangsat", 1.5}, {"bajingan", 1.5}, {"keparat", 1.5}, {"goblok", 1}, {"tolol", 1},
+	{"idiot", 1}, {"kampret", 1}, {"babi", 1}, {"brengsek", 1}, {"asu", 1}, {"tai", 1},
+	{"bego", 0.5}, {"bodoh", 0.5}, {"sialan", 0.5}, {"fuck", 1.5}, {"bitch", 1.5}, {"shit", 1}, {"stupid", 0.5},
+}
+
+// nToxicWord tracks the number of entries stored in the toxicWords array.
+var nToxicWord int = 19
+
+// ambangToksisitas is the minimum toxicity score for a comment to be flagged as toxic.
+var ambangToksisitas float64 = 1
+
+// sensorAktif controls whether abusive words are masked in comment listings.
+var sensorAktif bool = true
+
+// NaiveBayesModel is a trainable multinomial Naive Bayes sentiment classifier.
+// Word counts are kept per category in the same order as kategoriList.
+type NaiveBayesModel struct {
+	vocab      [NVOCAB]string // Distinct words seen during training
+	counts     [NVOCAB][3]int // Occurrences of each word per category
+	nVocab     int            // Number of distinct words in vocab
+	docCount   [3]int         // Number of training comments per category
+	tokenTotal [3]int         // Total number of tokens per category
+}
+
+// EvaluationReport holds the result of comparing predicted categories against moderator labels.
+// The confusion matrix is indexed as [actual][predicted] using the order of kategoriList.
+type EvaluationReport struct {
+	model          string      // Name of the evaluated classifier
+	matrix         [3][3]int   // Confusion matrix of actual versus predicted categories
+	precision      [3]float64  // Precision per category
+	recall         [3]float64  // Recall per category
+	f1             [3]float64  // F1 score per category
+	macroPrecision float64     // Unweighted mean of the per-category precision
+	macroRecall    float64     // Unweighted mean of the per-category recall
+	macroF1        float64     // Unweighted mean of the per-category F1 score
+	accuracy       float64     // Share of correctly classified comments
+	total          int         // Number of evaluated comments
+	folds          int         // Number of cross-validation folds, 0 when not cross-validated
+	foldAccuracy   [10]float64 // Accuracy of each cross-validation fold
+}
+
 func main() {
 	var input int
-	var userLogin User
+
+	// Accounts are only used by the interactive views, which work through sessions.
+	// The benchmark command has no session; see BenchmarkCommand.
+	if len(os.Args) > 1 && os.Args[1] == "benchmark" {
+		if err := BenchmarkCommand(os.Args[2:]); err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+		return
+	}
 
 	for input != 4 {
 		PrintTitle("Selamat datang di Tugas Besar Alpro Aplikasi Analisis Sentimen Kelompok 2")
@@ -62,7 +757,7 @@ func main() {
 
 		switch input {
 		case 1:
-			LoginView(&userLogin)
+			LoginView()
 		case 2:
 			RegisterView()
 		case 3:
@@ -75,9 +770,10 @@ func main() {
 
 // LoginView displays the login screen interface and handles the user authentication process.
 // It renders a navigation breadcrumb showing the current location in the application
-// and prints a formatted LOGIN title header.
-func LoginView(user *User) {
+// and prints a formatted LOGIN title header. A successful login starts a session for the user menu.
+func LoginView() {
 	var username, password string
+	var user User
 
 	PrintBreadcrumbs([255]string{"Login"}, 1)
 	PrintTitle("LOGIN")
@@ -85,13 +781,23 @@ func LoginView(user *User) {
 	for {
 		if err := LoginForm(&username, &password); err != nil {
 			fmt.Println(err.Error())
-		} else if err := FindUserByUsername(username, user); err != nil {
+		} else if err := CheckLoginLock(username); err != nil {
+			fmt.Println(err.Error())
+		} else if err := FindUserByUsername(username, &user); err != nil {
+			RecordLoginFailure(username, "username tidak ditemukan")
 			fmt.Println(err.Error())
 		} else if user.password != password {
+			RecordLoginFailure(username, "password salah")
 			fmt.Println("Password salah!")
+		} else if err := CheckUserAccess(user); err != nil {
+			RecordLoginAttempt(username, false, "ditolak karena sanksi")
+			fmt.Println(err.Error())
+		} else if sessionId, err := StartSession(user.id); err != nil {
+			fmt.Println(err.Error())
 		} else {
+			RecordLoginSuccess(username)
 			fmt.Println("Login berhasil!")
-			UserMenuView(*user)
+			UserMenuView(sessionId)
 			break
 		}
 
@@ -103,14 +809,65 @@ func LoginView(user *User) {
 
 // UserMenuView displays and handles the main user menu interface.
 // It presents a navigation breadcrumb and menu options for the authenticated user.
-func UserMenuView(user User) {
+// The session is validated before every action and the menu closes when it is no longer valid.
+func UserMenuView(sessionId int) {
 	var input int
+	var user User
 
 	for {
 		PrintBreadcrumbs([255]string{"User Menu"}, 1)
 		PrintTitle("USER MENU")
 
-		err := PrintMenu("Pilih Menu", [255]string{"Lihat Semua Komentar", "Buat Komentar", "Edit Komentar", "Hapus Komentar", "Keluar"}, 5, &input)
+		err := PrintMenu("Pilih Menu", [255]string{"Lihat Semua Komentar", "Buat Komentar", "Edit Komentar", "Hapus Komentar", "Balas Komentar", "Profil Saya", "Keluar"}, 7, &input)
+		if err != nil {
+			return
+		}
+
+		if input == 7 {
+			EndSession(sessionId)
+			fmt.Println("Anda telah logout.")
+			break
+		}
+
+		if err := ValidateSession(sessionId, &user); err != nil {
+			fmt.Println(err.Error())
+			return
+		}
+
+		switch input {
+		case 1:
+			LihatSemuaKomentarView(sessionId, false)
+		case 2:
+			BuatKomentarView(sessionId, false)
+		case 3:
+			EditKomentarView(sessionId, false)
+		case 4:
+			HapusKomentarView(sessionId, false)
+		case 5:
+			BalasKomentarView(sessionId)
+		case 6:
+			ProfilSayaView(sessionId, &user)
+		}
+
+		if !isSessionActive(sessionId) {
+			return
+		}
+	}
+}
+
+// ProfilSayaView lets the logged-in user manage their own account: change the password,
+// change the username, view comment statistics, and delete the account.
+// When the account is deleted or the session ends the user is reset to an empty User so the caller can log out.
+func ProfilSayaView(sessionId int, user *User) {
+	var input int
+
+	for {
+		PrintBreadcrumbs([255]string{"User Menu", "Profil Saya"}, 2)
+		PrintTitle("PROFIL SAYA")
+
+		fmt.Printf("ID: %d, Username: %s, Status: %s\n", user.id, user.username, userStatusLabel(*user))
+
+		err := PrintMenu("Pilih Menu", [255]string{"Ubah Password", "Ubah Username", "Statistik Komentar", "Hapus Akun", "Kembali"}, 5, &input)
 		if err != nil {
 			return
 		}
@@ -119,25 +876,83 @@ func UserMenuView(user User) {
 			break
 		}
 
+		if err := ValidateSession(sessionId, user); err != nil {
+			fmt.Println(err.Error())
+			*user = User{}
+			return
+		}
+
 		switch input {
 		case 1:
-			LihatSemuaKomentarView(false)
+			var passwordLama, passwordBaru string
+
+			if err := UbahPasswordForm(&passwordLama, &passwordBaru); err != nil {
+				fmt.Println(err.Error())
+			} else if err := ChangePassword(user.id, passwordLama, passwordBaru); err != nil {
+				fmt.Println(err.Error())
+			} else {
+				FindUserById(user.id, user)
+				fmt.Println("Password berhasil diubah!")
+			}
 		case 2:
-			BuatKomentarView(user, false)
+			var username string
+
+			fmt.Print("Masukkan Username Baru: ")
+			if _, err := fmt.Scan(&username); err != nil {
+				fmt.Println(err.Error())
+			} else if err := EditUser(username, "", user.id); err != nil {
+				fmt.Println(err.Error())
+			} else {
+				FindUserById(user.id, user)
+				fmt.Println("Username berhasil diubah!")
+			}
 		case 3:
-			EditKomentarView(user, false)
+			var stats UserCommentStats
+
+			GetUserCommentStats(user.id, &stats)
+
+			fmt.Println("Total komentar:", stats.total)
+			for i := 0; i < len(kategoriList); i++ {
+				fmt.Printf("Kategori %s: %d\n", kategoriList[i], stats.perKategori[i])
+			}
+			for i := 0; i < len(statusList); i++ {
+				fmt.Printf("Status %s: %d\n", statusList[i], stats.perStatus[i])
+			}
+			fmt.Println("Komentar toksik:", stats.toksik)
+			fmt.Println("Komentar duplikat:", stats.duplikat)
+			fmt.Scanln()
 		case 4:
-			HapusKomentarView(user, false)
+			var hapusKomentar bool
+
+			if err := HapusAkunForm(&hapusKomentar); err != nil {
+				fmt.Println(err.Error())
+			} else if err := DeleteOwnAccount(user.id, hapusKomentar); err != nil {
+				fmt.Println(err.Error())
+			} else {
+				fmt.Println("Akun berhasil dihapus!")
+				EndSession(sessionId)
+				*user = User{}
+				return
+			}
 		}
 	}
 }
 
 // LihatSemuaKomentarView displays all comments and provides options for searching,
 // sorting, and refreshing the comment list.
-func LihatSemuaKomentarView(isAdmin bool) {
+func LihatSemuaKomentarView(sessionId int, isAdmin bool) {
 	var input int
 	var commentsData [NMAX]Comment
 	var isFirstRun bool = true
+	var filter CommentFilter = CommentFilter{status: "approved"}
+	var pencarian *SearchMatcher
+	var halaman Pagination = Pagination{halaman: 1, ukuran: ukuranHalamanDefault}
+	var user User
+	var peringkat bool
+
+	if isAdmin {
+		filter.status = ""
+	}
 
 	for {
 		if isAdmin {
@@ -154,60 +969,373 @@ func LihatSemuaKomentarView(isAdmin bool) {
 				fmt.Scanln()
 				return
 			}
+			pencarian = nil
+			peringkat = false
+		}
+
+		if pencarian != nil {
+			fmt.Println("Pencarian:", pencarian.teks)
+		}
+		if isAdmin {
+			if filter.status == "" {
+				fmt.Println("Status: semua")
+			} else {
+				fmt.Println("Status:", filter.status)
+			}
+		}
+		if label := filterLabel(filter); label != "" {
+			fmt.Println("Filter:", label)
+		}
+
+		var total int
+		for i := 0; i < nComment; i++ {
+			if isVisibleComment(&commentsData, i, filter) {
+				total++
+			}
 		}
+		ClampPage(&halaman, total)
 
 		var n int = 1
 		for i := 0; i < nComment; i++ {
-			if commentsData[i].id != 0 {
-				fmt.Printf("%d. ID: %d, User ID: %d, Komentar: %s, Kategori: %s\n", n, commentsData[i].id, commentsData[i].userId, commentsData[i].komentar, commentsData[i].kategori)
-				n++
+			if peringkat {
+				if isVisibleComment(&commentsData, i, filter) {
+					printCommentEntry(commentsData[i], "", true, pencarian, &halaman, &n)
+				}
+			} else if isThreadRoot(&commentsData, i, filter) {
+				printCommentThread(&commentsData, i, 0, filter, pencarian, &halaman, &n)
 			}
 		}
+		fmt.Println(pageLabel(halaman, total, "komentar"))
 
-		err := PrintMenu("Pilih Menu", [255]string{"Cari Komentar", "Sortir Komentar", "Refresh", "Kembali"}, 4, &input)
+		var err error
+		if isAdmin {
+			err = PrintMenu("Pilih Menu", [255]string{"Cari Komentar", "Sortir Komentar", "Jelaskan Sentimen", "Hanya Komentar Toksik", "Filter Status", "Filter Komentar", "Halaman", "Refresh", "Kembali"}, 9, &input)
+		} else {
+			err = PrintMenu("Pilih Menu", [255]string{"Cari Komentar", "Sortir Komentar", "Jelaskan Sentimen", "Hanya Komentar Toksik", "Filter Komentar", "Halaman", "Refresh", "Kembali"}, 8, &input)
+			if input >= 5 {
+				input++
+			}
+		}
 		if err != nil {
 			return
 		}
 
-		if input == 4 {
+		if input == 9 {
 			break
 		}
 
-		isFirstRun = false
-
-		switch input {
+		if err := checkSession(sessionId, &user); err != nil {
+			fmt.Println(err.Error())
+			return
+		}
+
+		isFirstRun = false
+		if input != 3 && input != 7 {
+			halaman.halaman = 1
+		}
+
+		switch input {
 		case 1:
 			var search string
-			fmt.Print("Masukkan kata kunci untuk mencari komentar: ")
-			_, err = fmt.Scan(&search)
+			var mode int
+			var matcher SearchMatcher
+			var modes = [4]string{"query", "fuzzy", "relevansi", "regex"}
+
+			if err := PrintMenu("Mode Pencarian", [255]string{"Query", "Fuzzy (toleran salah ketik)", "Relevansi (BM25)", "Regex"}, 4, &mode); err != nil {
+				continue
+			}
+
+			switch mode {
+			case 1:
+				err = QueryForm(&search)
+			case 2:
+				err = FuzzyForm(&search)
+			case 3:
+				err = ReadLine("Masukkan kata kunci: ", &search)
+			case 4:
+				err = RegexForm(&search)
+			}
 			if err != nil {
+				continue
+			}
+
+			if err := NewSearchMatcher(modes[mode-1], search, &matcher); err != nil {
 				fmt.Println(err.Error())
 				continue
 			}
-			err = GetCommentsSearch(&commentsData, search)
+
+			err = SearchComments(&matcher, &commentsData)
 			if err != nil {
 				fmt.Println(err.Error())
 				fmt.Scanln()
 				continue
 			}
+			pencarian = &matcher
+			peringkat = matcher.mode == "relevansi"
 		case 2:
-			err = GetCommentsSort(&commentsData)
+			var spec SortSpec
+			var stats SortStats
+
+			if err := SortForm(&spec, kunciSortKomentar[:]); err != nil {
+				continue
+			}
+
+			err = GetCommentsSort(&commentsData, spec, &stats)
 			if err != nil {
 				fmt.Println(err.Error())
 				fmt.Scanln()
 				continue
 			}
+			fmt.Println(sortStatsLabel(spec, stats))
+			peringkat = false
 		case 3:
+			PenjelasanSentimenView(isAdmin)
+		case 4:
+			err = GetCommentsToxic(&commentsData)
+			if err != nil {
+				fmt.Println(err.Error())
+				fmt.Scanln()
+				continue
+			}
+			pencarian = nil
+			peringkat = false
+		case 5:
+			if err := StatusFilterForm(&filter.status); err != nil {
+				fmt.Println(err.Error())
+			}
+		case 6:
+			FilterKomentarView(&filter, user.id, isAdmin)
+		case 7:
+			if err := HalamanForm(&halaman, total); err != nil && err.Error() != "cancel" {
+				fmt.Println(err.Error())
+			}
+		case 8:
 			isFirstRun = true
 		}
 	}
 }
 
+// FilterKomentarView lets the user build the filter of the comment listing one condition at a time.
+// The conditions are combined, apply on top of search and sort results, and can be saved under a name.
+// Saved filters belong to ownerId (0 for the administrator) and only the owner's own filters can be loaded.
+// The moderation status is kept when a saved filter is loaded, so users keep seeing approved comments only.
+func FilterKomentarView(filter *CommentFilter, ownerId int, isAdmin bool) {
+	var input int
+
+	for {
+		if label := filterLabel(*filter); label != "" {
+			fmt.Println("Filter aktif:", label)
+		} else {
+			fmt.Println("Filter aktif: tidak ada")
+		}
+
+		err := PrintMenu("Filter Komentar", [255]string{"Kategori", "Penulis", "Rentang Tanggal", "Rentang Panjang", "Tanda Moderasi", "Sumber", "Simpan Filter", "Muat Filter", "Hapus Semua Filter", "Selesai"}, 10, &input)
+		if err != nil || input == 10 {
+			return
+		}
+
+		switch input {
+		case 1:
+			var kategori int
+
+			if err := PrintMenu("Pilih Kategori", [255]string{"Semua", kategoriList[0], kategoriList[1], kategoriList[2]}, 4, &kategori); err != nil {
+				continue
+			}
+			if kategori == 1 {
+				filter.kategori = ""
+			} else {
+				filter.kategori = kategoriList[kategori-2]
+			}
+		case 2:
+			var penulis string
+			var user User
+
+			fmt.Print("Username penulis atau @handle sumber (- untuk semua): ")
+			if _, err := fmt.Scan(&penulis); err != nil {
+				continue
+			}
+			if penulis == "-" {
+				filter.penulisId = 0
+				filter.sumber.handle = ""
+				continue
+			}
+			if penulis[0] == '@' {
+				filter.penulisId = 0
+				filter.sumber.handle = penulis[1:]
+				continue
+			}
+			if err := FindUserByUsername(penulis, &user); err != nil {
+				fmt.Println(err.Error())
+				continue
+			}
+			filter.penulisId = user.id
+			filter.sumber.handle = ""
+		case 3:
+			var dari, sampai time.Time
+
+			if err := TanggalForm("Dari tanggal", &dari); err != nil {
+				fmt.Println(err.Error())
+				continue
+			}
+			if err := TanggalForm("Sampai tanggal", &sampai); err != nil {
+				fmt.Println(err.Error())
+				continue
+			}
+
+			next := *filter
+			next.dari, next.sampai = dari, sampai
+			if err := ValidateCommentFilter(next); err != nil {
+				fmt.Println(err.Error())
+				continue
+			}
+			*filter = next
+		case 4:
+			next := *filter
+
+			fmt.Print("Panjang minimum (0 untuk tanpa batas): ")
+			if _, err := fmt.Scan(&next.panjangMin); err != nil {
+				continue
+			}
+			fmt.Print("Panjang maksimum (0 untuk tanpa batas): ")
+			if _, err := fmt.Scan(&next.panjangMax); err != nil {
+				continue
+			}
+
+			if err := ValidateCommentFilter(next); err != nil {
+				fmt.Println(err.Error())
+				continue
+			}
+			*filter = next
+		case 5:
+			TandaFilterForm(&filter.tanda)
+		case 6:
+			if err := SumberForm(&filter.sumber); err != nil {
+				fmt.Println(err.Error())
+			}
+		case 7:
+			var nama string
+
+			if err := ReadLine("Nama filter: ", &nama); err != nil {
+				continue
+			}
+			if err := SaveCommentFilter(ownerId, nama, *filter); err != nil {
+				fmt.Println(err.Error())
+				continue
+			}
+			fmt.Println("Filter berhasil disimpan.")
+		case 8:
+			var savedData [NFILTER]SavedFilter
+			var nSaved int
+			var menu [255]string
+			var pilihan int
+
+			if err := GetSavedFilters(ownerId, &savedData, &nSaved); err != nil {
+				fmt.Println(err.Error())
+				continue
+			}
+			for i := 0; i < nSaved; i++ {
+				menu[i] = savedData[i].nama
+				if label := filterLabel(savedData[i].filter); label != "" {
+					menu[i] += " (" + label + ")"
+				}
+			}
+
+			if err := PrintMenu("Pilih Filter", menu, nSaved, &pilihan); err != nil {
+				continue
+			}
+
+			status := filter.status
+			*filter = savedData[pilihan-1].filter
+			if !isAdmin {
+				filter.status = status
+			}
+		case 9:
+			*filter = CommentFilter{status: filter.status}
+		}
+	}
+}
+
+// PenjelasanSentimenView explains why a comment received its sentiment category.
+// It asks for a comment ID and prints every token with the lexicon entry or model feature it matched,
+// its weight, the negations and intensifiers applied, and the final score against the thresholds.
+func PenjelasanSentimenView(isAdmin bool) {
+	var inputId int
+	var comment Comment
+	var result SentimentResult
+	var model NaiveBayesModel
+
+	if isAdmin {
+		PrintBreadcrumbs([255]string{"Admin Menu", "Lihat Komentar", "Lihat Semua Komentar", "Jelaskan Sentimen"}, 4)
+	} else {
+		PrintBreadcrumbs([255]string{"User Menu", "Lihat Semua Komentar", "Jelaskan Sentimen"}, 3)
+	}
+	PrintTitle("JELASKAN SENTIMEN")
+
+	for {
+		fmt.Print("ID: ")
+		_, err := fmt.Scan(&inputId)
+		if err != nil {
+			fmt.Println(err.Error())
+		} else if err := FindCommentById(inputId, &comment); err != nil {
+			fmt.Println(err.Error())
+		} else if !isAdmin && comment.status != "approved" {
+			fmt.Printf("komentar dengan ID %d tidak ditemukan\n", inputId)
+		} else {
+			break
+		}
+
+		if err := ConfirmForm("Apakah Anda ingin mencoba lagi?"); err != nil {
+			return
+		}
+	}
+
+	AnalyzeSentiment(comment.komentar, &result)
+	TrainNaiveBayesAll(&model)
+
+	fmt.Println("Komentar:", comment.komentar)
+	fmt.Println("Kategori tersimpan:", kategoriLabel(comment))
+	fmt.Printf("\n%-16s%-14s%8s%8s%8s%12s%30s\n", "Token", "Cocok", "Bobot", "Faktor", "Negasi", "Kontribusi", "Fitur NB (pos/net/neg)")
+
+	for i := 0; i < result.nToken; i++ {
+		t := result.tokens[i]
+		fitur := fmt.Sprintf("%.2f/%.2f/%.2f", NaiveBayesWordLogProb(&model, t.token, 0), NaiveBayesWordLogProb(&model, t.token, 1), NaiveBayesWordLogProb(&model, t.token, 2))
+
+		switch t.sumber {
+		case "leksikon", "emoji":
+			negasi := "-"
+			if t.negated {
+				negasi = "ya"
+			}
+			fmt.Printf("%-16s%-14s%8.2f%8.2f%8s%12.2f%30s\n", t.token, t.sumber, t.bobot, t.faktor, negasi, t.kontribusi, fitur)
+		case "intensifier":
+			fmt.Printf("%-16s%-14s%8.2f%8s%8s%12s%30s\n", t.token, t.sumber, t.bobot, "-", "-", "-", fitur)
+		case "negasi":
+			fmt.Printf("%-16s%-14s%8s%8s%8s%12s%30s\n", t.token, t.sumber, "-", "-", "-", "-", fitur)
+		default:
+			fmt.Printf("%-16s%-14s%8s%8s%8s%12s%30s\n", t.token, "-", "-", "-", "-", "-", fitur)
+		}
+	}
+
+	fmt.Printf("\nSkor akhir: %.2f (positif jika >= %.2f, negatif jika <= %.2f, selain itu netral)\n", result.skor, ambangPositif, ambangNegatif)
+	fmt.Println("Kategori leksikon:", result.kategori)
+	fmt.Printf("Keyakinan: %.2f (perlu ditinjau jika < %.2f)\n", result.keyakinan, ambangKeyakinan)
+	fmt.Println("Kategori Naive Bayes:", PredictNaiveBayes(&model, comment.komentar))
+
+	for a := 0; a < comment.nAspek; a++ {
+		var aspect Aspect
+		if err := FindAspectById(comment.aspek[a].aspekId, &aspect); err == nil {
+			fmt.Printf("Aspek %s: skor %.2f, kategori %s\n", aspect.nama, comment.aspek[a].skor, comment.aspek[a].kategori)
+		}
+	}
+	fmt.Scanln()
+}
+
 // BuatKomentarView displays the comment creation interface and handles the process of creating a new comment.
 // It renders a navigation breadcrumb showing the current location in the application
 // and prints a formatted BUAT KOMENTAR (Create Comment) title header.
-func BuatKomentarView(user User, isAdmin bool) {
-	var komentar, kategori string
+func BuatKomentarView(sessionId int, isAdmin bool) {
+	var komentar string
+	var user User
 
 	if isAdmin {
 		PrintBreadcrumbs([255]string{"Admin Menu", "Lihat Komentar", "Buat Komentar"}, 3)
@@ -217,11 +1345,39 @@ func BuatKomentarView(user User, isAdmin bool) {
 	PrintTitle("BUAT KOMENTAR")
 
 	for {
-		if err := KomentarForm(&komentar, &kategori, false); err != nil {
+		var original Comment
+		var tindakan int
+
+		if err := KomentarForm(&komentar, false); err != nil {
 			fmt.Println(err.Error())
-		} else if err := CreateComment(user, komentar, kategori); err != nil {
+		} else if err := checkSession(sessionId, &user); err != nil {
+			fmt.Println(err.Error())
+			return
+		} else if err := DuplikatForm(komentar, &original, &tindakan); err != nil {
+			fmt.Println(err.Error())
+		} else if tindakan == 1 {
+			fmt.Println("Komentar ditolak karena duplikat.")
+			break
+		} else if tindakan == 3 {
+			if err := MergeDuplicateComment(user, original.id); err != nil {
+				fmt.Println(err.Error())
+			} else {
+				fmt.Printf("Komentar digabungkan dengan komentar ID %d!\n", original.id)
+				break
+			}
+		} else if err := CreateComment(user, komentar, original.id); err != nil {
 			fmt.Println(err.Error())
 		} else {
+			if isAdmin {
+				var sumber CommentSource
+
+				if err := SumberForm(&sumber); err != nil {
+					fmt.Println(err.Error())
+				} else if err := SetCommentSource(idComment-1, sumber); err != nil {
+					fmt.Println(err.Error())
+				}
+			}
+
 			fmt.Println("Komentar berhasil dibuat!")
 			break
 		}
@@ -232,11 +1388,66 @@ func BuatKomentarView(user User, isAdmin bool) {
 	}
 }
 
+// BalasKomentarView lists the approved comments and lets the user reply to one of them.
+// Replies skip the duplicate check, since short replies such as "setuju" are expected to repeat.
+func BalasKomentarView(sessionId int) {
+	var commentsData [NMAX]Comment
+	var user User
+
+	PrintBreadcrumbs([255]string{"User Menu", "Balas Komentar"}, 2)
+	PrintTitle("BALAS KOMENTAR")
+
+	err := GetComments(&commentsData)
+	if err != nil {
+		fmt.Println(err.Error())
+		fmt.Scanln()
+		return
+	}
+
+	var n int = 1
+	for i := 0; i < nComment; i++ {
+		if isThreadRoot(&commentsData, i, CommentFilter{status: "approved"}) {
+			printCommentThread(&commentsData, i, 0, CommentFilter{status: "approved"}, nil, nil, &n)
+		}
+	}
+
+	var inputId int
+	var parent Comment
+	var komentar string
+
+	for {
+		fmt.Print("ID komentar yang dibalas: ")
+		_, err := fmt.Scan(&inputId)
+		if err != nil {
+			fmt.Println(err.Error())
+		} else if err := FindCommentById(inputId, &parent); err != nil {
+			fmt.Println(err.Error())
+		} else if parent.status != "approved" {
+			fmt.Println("komentar tersebut tidak dapat dibalas")
+		} else if err := KomentarForm(&komentar, false); err != nil {
+			fmt.Println(err.Error())
+		} else if err := checkSession(sessionId, &user); err != nil {
+			fmt.Println(err.Error())
+			return
+		} else if err := CreateReply(user, parent.id, komentar); err != nil {
+			fmt.Println(err.Error())
+		} else {
+			fmt.Println("Balasan berhasil dibuat!")
+			break
+		}
+
+		if err := ConfirmForm("Apakah Anda ingin mencoba lagi?"); err != nil {
+			break
+		}
+	}
+}
+
 // EditKomentarView displays the comment editing interface and handles the process of modifying existing comments.
 // It renders a navigation breadcrumb showing the current location in the application
 // and prints a formatted EDIT KOMENTAR (Edit Comment) title header.
-func EditKomentarView(user User, isAdmin bool) {
+func EditKomentarView(sessionId int, isAdmin bool) {
 	var commentsData [NMAX]Comment
+	var user User
 
 	if isAdmin {
 		PrintBreadcrumbs([255]string{"Admin Menu", "Lihat Komentar", "Edit Komentar"}, 3)
@@ -245,6 +1456,11 @@ func EditKomentarView(user User, isAdmin bool) {
 	}
 	PrintTitle("EDIT KOMENTAR")
 
+	if err := checkSession(sessionId, &user); err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+
 	err := GetComments(&commentsData)
 	if err != nil {
 		fmt.Println(err.Error())
@@ -254,18 +1470,18 @@ func EditKomentarView(user User, isAdmin bool) {
 
 	var n int = 1
 	for i := 0; i < nComment; i++ {
-		if commentsData[i].userId == user.id && !isAdmin {
-			fmt.Printf("%d. ID: %d, Komentar: %s, Kategori: %s\n", n, commentsData[i].id, commentsData[i].komentar, commentsData[i].kategori)
+		if commentsData[i].userId == user.id && !isAdmin && commentsData[i].status != "removed" {
+			fmt.Printf("%d. ID: %d, Komentar: %s, Kategori: %s, Status: %s\n", n, commentsData[i].id, displayKomentar(commentsData[i]), kategoriLabel(commentsData[i]), commentsData[i].status)
 			n++
 		} else if isAdmin {
-			fmt.Printf("%d. ID: %d, User ID: %d, Komentar: %s, Kategori: %s\n", n, commentsData[i].id, commentsData[i].userId, commentsData[i].komentar, commentsData[i].kategori)
+			fmt.Printf("%d. ID: %d, Penulis: %s, Komentar: %s, Kategori: %s, Status: %s\n", n, commentsData[i].id, authorLabel(commentsData[i]), displayKomentar(commentsData[i]), kategoriLabel(commentsData[i]), commentsData[i].status)
 			n++
 		}
 	}
 
 	var inputId int
 	var commentToEdit Comment
-	var komentar, kategori string
+	var komentar string
 
 	for {
 		fmt.Print("ID: ")
@@ -274,11 +1490,14 @@ func EditKomentarView(user User, isAdmin bool) {
 			fmt.Println(err.Error())
 		} else if err := FindCommentById(inputId, &commentToEdit); err != nil {
 			fmt.Println(err.Error())
-		} else if commentToEdit.userId != user.id && !isAdmin {
+		} else if (commentToEdit.userId != user.id || commentToEdit.status == "removed") && !isAdmin {
 			fmt.Println("Anda tidak memiliki izin untuk mengedit komentar ini.")
-		} else if err := KomentarForm(&komentar, &kategori, true); err != nil {
+		} else if err := KomentarForm(&komentar, true); err != nil {
 			fmt.Println(err.Error())
-		} else if err := EditComment(komentar, kategori, commentToEdit.id); err != nil {
+		} else if err := checkSession(sessionId, &user); err != nil {
+			fmt.Println(err.Error())
+			return
+		} else if err := EditComment(komentar, commentToEdit.id); err != nil {
 			fmt.Println(err.Error())
 		} else {
 			fmt.Println("Komentar berhasil diubah!")
@@ -294,8 +1513,9 @@ func EditKomentarView(user User, isAdmin bool) {
 // HapusKomentarView displays the comment deletion interface and handles the process of removing existing comments.
 // It renders a navigation breadcrumb showing the current location in the application
 // and prints a formatted HAPUS KOMENTAR (Delete Comment) title header.
-func HapusKomentarView(user User, isAdmin bool) {
+func HapusKomentarView(sessionId int, isAdmin bool) {
 	var commentsData [NMAX]Comment
+	var user User
 
 	if isAdmin {
 		PrintBreadcrumbs([255]string{"Admin Menu", "Lihat Komentar", "Hapus Komentar"}, 3)
@@ -304,6 +1524,11 @@ func HapusKomentarView(user User, isAdmin bool) {
 	}
 	PrintTitle("HAPUS KOMENTAR")
 
+	if err := checkSession(sessionId, &user); err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+
 	err := GetComments(&commentsData)
 	if err != nil {
 		fmt.Println(err.Error())
@@ -313,11 +1538,11 @@ func HapusKomentarView(user User, isAdmin bool) {
 
 	var n int = 1
 	for i := 0; i < nComment; i++ {
-		if commentsData[i].userId == user.id && !isAdmin {
-			fmt.Printf("%d. ID: %d, Komentar: %s, Kategori: %s\n", n, commentsData[i].id, commentsData[i].komentar, commentsData[i].kategori)
+		if commentsData[i].userId == user.id && !isAdmin && commentsData[i].status != "removed" {
+			fmt.Printf("%d. ID: %d, Komentar: %s, Kategori: %s, Status: %s\n", n, commentsData[i].id, displayKomentar(commentsData[i]), kategoriLabel(commentsData[i]), commentsData[i].status)
 			n++
 		} else if isAdmin {
-			fmt.Printf("%d. ID: %d, User ID: %d, Komentar: %s, Kategori: %s\n", n, commentsData[i].id, commentsData[i].userId, commentsData[i].komentar, commentsData[i].kategori)
+			fmt.Printf("%d. ID: %d, Penulis: %s, Komentar: %s, Kategori: %s, Status: %s\n", n, commentsData[i].id, authorLabel(commentsData[i]), displayKomentar(commentsData[i]), kategoriLabel(commentsData[i]), commentsData[i].status)
 			n++
 		}
 	}
@@ -332,8 +1557,11 @@ func HapusKomentarView(user User, isAdmin bool) {
 			fmt.Println(err.Error())
 		} else if err := FindCommentById(inputId, &commentToDelete); err != nil {
 			fmt.Println(err.Error())
-		} else if commentToDelete.userId != user.id && !isAdmin {
+		} else if (commentToDelete.userId != user.id || commentToDelete.status == "removed") && !isAdmin {
 			fmt.Println("Anda tidak memiliki izin untuk menghapus komentar ini.")
+		} else if err := checkSession(sessionId, &user); err != nil {
+			fmt.Println(err.Error())
+			return
 		} else if err := DeleteComment(commentToDelete.id); err != nil {
 			fmt.Println(err.Error())
 		} else {
@@ -394,7 +1622,16 @@ func AdminMenuView() {
 				return
 			}
 
+			if err := CheckLoginLock(adminLoginName); err != nil {
+				fmt.Println(err.Error())
+				if err := ConfirmForm("Apakah Anda ingin mencoba lagi?"); err != nil {
+					return
+				}
+				continue
+			}
+
 			if password != passwordAdmin {
+				RecordLoginFailure(adminLoginName, "password salah")
 				fmt.Println("Password salah!")
 				if err := ConfirmForm("Apakah Anda ingin mencoba lagi?"); err != nil {
 					return
@@ -402,15 +1639,16 @@ func AdminMenuView() {
 				continue
 			}
 
+			RecordLoginSuccess(adminLoginName)
 			isLoggedIn = true
 		}
 
-		err := PrintMenu("Pilih Menu", [255]string{"Lihat Komentar", "Lihat User", "Lihat Grafik", "Keluar"}, 4, &input)
+		err := PrintMenu("Pilih Menu", [255]string{"Lihat Komentar", "Lihat User", "Lihat Grafik", "Evaluasi Klasifikasi", "Perlu Ditinjau", "Kelola Aspek", "Kelola Kata Kasar", "Klaster Duplikat", "Benchmark Algoritma", "Keluar"}, 10, &input)
 		if err != nil {
 			return
 		}
 
-		if input == 4 {
+		if input == 10 {
 			break
 		}
 
@@ -421,6 +1659,18 @@ func AdminMenuView() {
 			LihatUserView()
 		case 3:
 			LihatGrafikView()
+		case 4:
+			EvaluasiKlasifikasiView()
+		case 5:
+			PerluDitinjauView()
+		case 6:
+			KelolaAspekView()
+		case 7:
+			KelolaKataKasarView()
+		case 8:
+			KlasterDuplikatView()
+		case 9:
+			BenchmarkView()
 		}
 	}
 }
@@ -435,24 +1685,103 @@ func LihatKomentarAdminView() {
 		PrintBreadcrumbs([255]string{"Admin Menu", "Lihat Komentar"}, 2)
 		PrintTitle("LIHAT KOMENTAR")
 
-		err := PrintMenu("Pilih Menu", [255]string{"Lihat Semua Komentar", "Buat Komentar", "Ubah Komentar", "Delete Komentar", "Kembali"}, 5, &input)
+		err := PrintMenu("Pilih Menu", [255]string{"Lihat Semua Komentar", "Buat Komentar", "Ubah Komentar", "Delete Komentar", "Moderasi Komentar", "Impor Komentar", "Kembali"}, 7, &input)
 		if err != nil {
 			return
 		}
 
-		if input == 5 {
+		if input == 7 {
 			break
 		}
 
 		switch input {
 		case 1:
-			LihatSemuaKomentarView(true)
+			LihatSemuaKomentarView(0, true)
 		case 2:
-			BuatKomentarView(User{}, true)
+			BuatKomentarView(0, true)
 		case 3:
-			EditKomentarView(User{}, true)
+			EditKomentarView(0, true)
 		case 4:
-			HapusKomentarView(User{}, true)
+			HapusKomentarView(0, true)
+		case 5:
+			ModerasiKomentarView()
+		case 6:
+			ImporKomentarView()
+		}
+	}
+}
+
+// ImporKomentarView imports comments with their source from a file chosen by the administrator.
+func ImporKomentarView() {
+	var path string
+	var diimpor, dilewati int
+
+	PrintBreadcrumbs([255]string{"Admin Menu", "Lihat Komentar", "Impor Komentar"}, 3)
+	PrintTitle("IMPOR KOMENTAR")
+
+	fmt.Println("Format per baris: platform|id_post|id_komentar|handle|komentar")
+	if err := ReadLine("Path file: ", &path); err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+
+	err := ImportComments(path, &diimpor, &dilewati)
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+
+	fmt.Printf("%d komentar diimpor, %d baris dilewati.\n", diimpor, dilewati)
+}
+
+// ModerasiKomentarView displays the moderation interface for administrators.
+// It lists the comments with the chosen status, then lets the administrator pick a comment,
+// move it to one of the statuses allowed from its current status, and record the reason.
+func ModerasiKomentarView() {
+	var statusFilter string
+	var inputId int
+	var comment Comment
+	var status, alasan string
+
+	PrintBreadcrumbs([255]string{"Admin Menu", "Lihat Komentar", "Moderasi Komentar"}, 3)
+	PrintTitle("MODERASI KOMENTAR")
+
+	if nComment == 0 {
+		fmt.Println("tidak ada komentar yang tersedia")
+		return
+	}
+
+	if err := StatusFilterForm(&statusFilter); err != nil {
+		return
+	}
+
+	var n int = 1
+	for i := 0; i < nComment; i++ {
+		if statusFilter == "" || comments[i].status == statusFilter {
+			fmt.Printf("%d. ID: %d, Penulis: %s, Komentar: %s, Status: %s (%s, oleh %s)%s\n", n, comments[i].id, authorLabel(comments[i]), displayKomentar(comments[i]), comments[i].status, comments[i].alasanStatus, comments[i].statusOleh, flagLabel(comments[i]))
+			n++
+		}
+	}
+
+	for {
+		fmt.Print("ID: ")
+		_, err := fmt.Scan(&inputId)
+		if err != nil {
+			fmt.Println(err.Error())
+		} else if err := FindCommentById(inputId, &comment); err != nil {
+			fmt.Println(err.Error())
+		} else if err := StatusForm(comment.status, &status); err != nil {
+			fmt.Println(err.Error())
+		} else if err := ReadLine("Masukkan Alasan: ", &alasan); err != nil {
+			fmt.Println(err.Error())
+		} else if err := ChangeCommentStatus(comment.id, status, alasan, "admin"); err != nil {
+			fmt.Println(err.Error())
+		} else {
+			fmt.Println("Status komentar berhasil diubah!")
+			break
+		}
+
+		if err := ConfirmForm("Apakah Anda ingin mencoba lagi?"); err != nil {
+			break
 		}
 	}
 }
@@ -466,12 +1795,12 @@ func LihatUserView() {
 		PrintBreadcrumbs([255]string{"Admin Menu", "Lihat User"}, 2)
 		PrintTitle("LIHAT USER")
 
-		err := PrintMenu("Pilih Menu", [255]string{"Lihat Semua User", "Buat User", "Ubah User", "Hapus User", "Kembali"}, 5, &input)
+		err := PrintMenu("Pilih Menu", [255]string{"Lihat Semua User", "Buat User", "Ubah User", "Hapus User", "Sanksi User", "Keamanan Login", "Kembali"}, 7, &input)
 		if err != nil {
 			return
 		}
 
-		if input == 5 {
+		if input == 7 {
 			break
 		}
 
@@ -484,17 +1813,262 @@ func LihatUserView() {
 			EditUserAdminView()
 		case 4:
 			HapusUserAdminView()
+		case 5:
+			SanksiUserAdminView()
+		case 6:
+			KeamananLoginAdminView()
 		}
 	}
 }
 
-// LihatSemuaUserAdminView displays all users in the system for administrative review.
-// It renders a navigation breadcrumb showing the current location in the application
+// SanksiUserAdminView displays the sanction management interface for administrators.
+// It lets the administrator suspend a user for a number of days, ban a user, lift a sanction,
+// and list the history of sanctions with their reasons.
+func SanksiUserAdminView() {
+	var input int
+
+	for {
+		PrintBreadcrumbs([255]string{"Admin Menu", "Lihat User", "Sanksi User"}, 3)
+		PrintTitle("SANKSI USER")
+
+		err := PrintMenu("Pilih Menu", [255]string{"Tangguhkan User", "Blokir User", "Cabut Sanksi", "Lihat Daftar Sanksi", "Kembali"}, 5, &input)
+		if err != nil {
+			return
+		}
+
+		if input == 5 {
+			break
+		}
+
+		if input == 4 {
+			var sanctionsData [NMAX]Sanction
+
+			if err := GetSanctions(&sanctionsData); err != nil {
+				fmt.Println(err.Error())
+				continue
+			}
+
+			for i := 0; i < nSanction; i++ {
+				var user User
+				username := "(user terhapus)"
+				if err := FindUserById(sanctionsData[i].userId, &user); err == nil {
+					username = user.username
+				}
+
+				fmt.Printf("%d. %s, User: %s (ID %d), Jenis: %s, Alasan: %s", i+1, sanctionsData[i].dibuat.Format("02-01-2006 15:04"), username, sanctionsData[i].userId, sanctionsData[i].jenis, sanctionsData[i].alasan)
+				if sanctionsData[i].jenis == "suspend" {
+					fmt.Printf(", Sampai: %s", sanctionsData[i].sampai.Format("02-01-2006 15:04"))
+				}
+				fmt.Println()
+			}
+			continue
+		}
+
+		var inputId, hari int
+		var user User
+		var alasan string
+
+		fmt.Print("ID User: ")
+		_, err = fmt.Scan(&inputId)
+		if err != nil {
+			fmt.Println(err.Error())
+			continue
+		} else if err := FindUserById(inputId, &user); err != nil {
+			fmt.Println(err.Error())
+			continue
+		}
+
+		if input == 1 {
+			fmt.Print("Lama penangguhan (hari): ")
+			_, err = fmt.Scan(&hari)
+			if err != nil {
+				fmt.Println(err.Error())
+				continue
+			}
+		}
+
+		if err := ReadLine("Masukkan Alasan: ", &alasan); err != nil {
+			fmt.Println(err.Error())
+			continue
+		}
+
+		switch input {
+		case 1:
+			err = SuspendUser(user.id, time.Now().AddDate(0, 0, hari), alasan)
+		case 2:
+			err = BanUser(user.id, alasan)
+		case 3:
+			err = LiftSanction(user.id, alasan)
+		}
+
+		if err != nil {
+			fmt.Println(err.Error())
+		} else {
+			fmt.Println("Sanksi berhasil disimpan!")
+		}
+	}
+}
+
+// KeamananLoginAdminView displays the login security interface for administrators.
+// It lists the locked usernames, lets the administrator unlock one, shows the login history,
+// and changes the lockout policy.
+func KeamananLoginAdminView() {
+	var input int
+
+	for {
+		PrintBreadcrumbs([255]string{"Admin Menu", "Lihat User", "Keamanan Login"}, 3)
+		PrintTitle("KEAMANAN LOGIN")
+
+		fmt.Printf("Kebijakan: terkunci setelah %d kali gagal, durasi awal %s, maksimum %s\n", batasGagalLogin, durasiKunciDasar.String(), durasiKunciMaks.String())
+		fmt.Printf("Password: minimal %d karakter, huruf kecil: %t, huruf besar: %t, angka: %t, simbol: %t, tolak sama username: %t\n",
+			kebijakanPassword.panjangMin, kebijakanPassword.wajibHurufKecil, kebijakanPassword.wajibHurufBesar,
+			kebijakanPassword.wajibAngka, kebijakanPassword.wajibSimbol, kebijakanPassword.tolakSamaUsername)
+
+		var n int = 1
+		for i := 0; i < nLoginGuard; i++ {
+			if time.Now().Before(loginGuards[i].terkunciSampai) {
+				fmt.Printf("%d. Username: %s, Terkunci hingga: %s, Penguncian ke-%d\n", n, loginGuardName(loginGuards[i]), loginGuards[i].terkunciSampai.Format("02-01-2006 15:04:05"), loginGuards[i].penguncian)
+				n++
+			}
+		}
+		for i := 0; i < nUnknownLoginGuard; i++ {
+			if time.Now().Before(unknownLoginGuards[i].terkunciSampai) {
+				fmt.Printf("%d. Username: %s (tidak terdaftar), Terkunci hingga: %s, Penguncian ke-%d\n", n, unknownLoginGuards[i].username, unknownLoginGuards[i].terkunciSampai.Format("02-01-2006 15:04:05"), unknownLoginGuards[i].penguncian)
+				n++
+			}
+		}
+		if n == 1 {
+			fmt.Println("Tidak ada akun yang terkunci.")
+		}
+
+		err := PrintMenu("Pilih Menu", [255]string{"Buka Kunci Akun", "Riwayat Login", "Atur Kebijakan Penguncian", "Atur Kebijakan Password", "Sesi Aktif", "Atur Batas Sesi", "Kembali"}, 7, &input)
+		if err != nil {
+			return
+		}
+
+		if input == 7 {
+			break
+		}
+
+		switch input {
+		case 1:
+			var username string
+
+			fmt.Print("Masukkan Username: ")
+			_, err := fmt.Scan(&username)
+			if err != nil {
+				fmt.Println(err.Error())
+			} else if err := UnlockLogin(username); err != nil {
+				fmt.Println(err.Error())
+			} else {
+				fmt.Println("Akun berhasil dibuka!")
+			}
+		case 2:
+			var logData [NMAX]LoginRecord
+
+			if err := GetLoginLog(&logData); err != nil {
+				fmt.Println(err.Error())
+				continue
+			}
+
+			for i := 0; i < nLoginLog; i++ {
+				hasil := "gagal"
+				if logData[i].berhasil {
+					hasil = "berhasil"
+				}
+				fmt.Printf("%d. %s, Username: %s, Hasil: %s, Keterangan: %s\n", i+1, logData[i].waktu.Format("02-01-2006 15:04:05"), logData[i].username, hasil, logData[i].keterangan)
+			}
+		case 3:
+			var batas, detik int
+
+			fmt.Print("Jumlah gagal sebelum terkunci: ")
+			_, err := fmt.Scan(&batas)
+			if err != nil {
+				fmt.Println(err.Error())
+				continue
+			}
+
+			fmt.Print("Durasi penguncian awal (detik): ")
+			_, err = fmt.Scan(&detik)
+			if err != nil {
+				fmt.Println(err.Error())
+			} else if err := SetLockoutPolicy(batas, time.Duration(detik)*time.Second); err != nil {
+				fmt.Println(err.Error())
+			} else {
+				fmt.Println("Kebijakan penguncian berhasil diubah!")
+			}
+		case 4:
+			var policy PasswordPolicy
+
+			if err := PasswordPolicyForm(&policy); err != nil {
+				fmt.Println(err.Error())
+			} else if err := SetPasswordPolicy(policy); err != nil {
+				fmt.Println(err.Error())
+			} else {
+				fmt.Println("Kebijakan password berhasil diubah!")
+			}
+		case 5:
+			var sessionsData [NMAX]Session
+			var inputId int
+
+			if err := GetSessions(&sessionsData); err != nil {
+				fmt.Println(err.Error())
+				continue
+			}
+
+			for i := 0; i < nSession; i++ {
+				var user User
+				username := "(user terhapus)"
+				if err := FindUserById(sessionsData[i].userId, &user); err == nil {
+					username = user.username
+				}
+
+				fmt.Printf("%d. ID: %d, User: %s (ID %d), Mulai: %s, Terakhir Aktif: %s\n", i+1, sessionsData[i].id, username, sessionsData[i].userId, sessionsData[i].dibuat.Format("02-01-2006 15:04:05"), sessionsData[i].terakhirAktif.Format("02-01-2006 15:04:05"))
+			}
+
+			fmt.Print("ID sesi yang diakhiri (0 untuk kembali): ")
+			_, err := fmt.Scan(&inputId)
+			if err != nil {
+				fmt.Println(err.Error())
+			} else if inputId != 0 {
+				if err := EndSession(inputId); err != nil {
+					fmt.Println(err.Error())
+				} else {
+					fmt.Println("Sesi berhasil diakhiri!")
+				}
+			}
+		case 6:
+			var durasiMenit, idleMenit int
+
+			fmt.Printf("Durasi maksimum sesi (menit, saat ini %s): ", durasiSesi.String())
+			_, err := fmt.Scan(&durasiMenit)
+			if err != nil {
+				fmt.Println(err.Error())
+				continue
+			}
+
+			fmt.Printf("Batas idle (menit, saat ini %s): ", batasIdle.String())
+			_, err = fmt.Scan(&idleMenit)
+			if err != nil {
+				fmt.Println(err.Error())
+			} else if err := SetSessionPolicy(time.Duration(durasiMenit)*time.Minute, time.Duration(idleMenit)*time.Minute); err != nil {
+				fmt.Println(err.Error())
+			} else {
+				fmt.Println("Batas sesi berhasil diubah!")
+			}
+		}
+	}
+}
+
+// LihatSemuaUserAdminView displays all users in the system for administrative review.
+// It renders a navigation breadcrumb showing the current location in the application
 // and prints a formatted LIHAT SEMUA USER (View All Users) title header.
 func LihatSemuaUserAdminView() {
 	var input int
 	var usersData [NMAX]User
 	var isFirstRun bool = true
+	var pencarian *SearchMatcher
+	var halaman Pagination = Pagination{halaman: 1, ukuran: ukuranHalamanDefault}
 
 	for {
 		PrintBreadcrumbs([255]string{"Admin Menu", "Lihat User", "Lihat Semua User"}, 3)
@@ -515,50 +2089,112 @@ func LihatSemuaUserAdminView() {
 				fmt.Scanln()
 				return
 			}
+			pencarian = nil
 		}
 
-		var n int = 1
+		if pencarian != nil {
+			fmt.Println("Pencarian:", pencarian.teks)
+		}
+
+		var total int
 		for i := 0; i < nUser; i++ {
 			if usersData[i].id != 0 {
-				fmt.Printf("%d. ID: %d, Username: %s\n", n, usersData[i].id, usersData[i].username)
+				total++
+			}
+		}
+		ClampPage(&halaman, total)
+
+		var n int = 1
+		for i := 0; i < nUser; i++ {
+			if usersData[i].id != 0 && !isInPage(&halaman, n) {
+				n++
+			} else if usersData[i].id != 0 {
+				var spans [NTOKEN][2]int
+				var nSpan int
+
+				username := usersData[i].username
+				if pencarian != nil {
+					FindMatchSpans(pencarian, username, &spans, &nSpan)
+					username = FormatSearchResult(username, &spans, nSpan)
+				}
+
+				fmt.Printf("%d. ID: %d, Username: %s, Status: %s%s\n", n, usersData[i].id, username, userStatusLabel(usersData[i]), matchCountLabel(pencarian, nSpan))
 				n++
 			}
 		}
+		fmt.Println(pageLabel(halaman, total, "user"))
 
-		err := PrintMenu("Pilih Menu", [255]string{"Cari User", "Sortir User", "Refresh", "Kembali"}, 4, &input)
+		err := PrintMenu("Pilih Menu", [255]string{"Cari User", "Sortir User", "Halaman", "Refresh", "Kembali"}, 5, &input)
 		if err != nil {
 			return
 		}
 
 		isFirstRun = false
 
-		if input == 4 {
+		if input == 5 {
 			break
 		}
+		if input != 3 {
+			halaman.halaman = 1
+		}
 
 		switch input {
 		case 1:
 			var search string
-			fmt.Print("Masukkan kata kunci untuk mencari user: ")
-			_, err = fmt.Scan(&search)
+			var mode int
+			var matcher SearchMatcher
+			var modes = [3]string{"kata", "fuzzyNama", "regex"}
+
+			if err := PrintMenu("Mode Pencarian", [255]string{"Kata Kunci", "Fuzzy (toleran salah ketik)", "Regex"}, 3, &mode); err != nil {
+				continue
+			}
+
+			switch mode {
+			case 1:
+				fmt.Print("Masukkan kata kunci untuk mencari user: ")
+				_, err = fmt.Scan(&search)
+			case 2:
+				err = FuzzyForm(&search)
+			case 3:
+				err = RegexForm(&search)
+			}
 			if err != nil {
 				fmt.Println(err.Error())
 				continue
 			}
-			err = GetUsersSearch(&usersData, search)
+
+			if err := NewSearchMatcher(modes[mode-1], search, &matcher); err != nil {
+				fmt.Println(err.Error())
+				continue
+			}
+
+			err = SearchUsers(&matcher, &usersData)
 			if err != nil {
 				fmt.Println(err.Error())
 				fmt.Scanln()
 				continue
 			}
+			pencarian = &matcher
 		case 2:
-			err = GetUsersSort(&usersData)
+			var spec SortSpec
+			var stats SortStats
+
+			if err := SortForm(&spec, kunciSortUser[:]); err != nil {
+				continue
+			}
+
+			err = GetUsersSort(&usersData, spec, &stats)
 			if err != nil {
 				fmt.Println(err.Error())
 				fmt.Scanln()
 				continue
 			}
+			fmt.Println(sortStatsLabel(spec, stats))
 		case 3:
+			if err := HalamanForm(&halaman, total); err != nil && err.Error() != "cancel" {
+				fmt.Println(err.Error())
+			}
+		case 4:
 			isFirstRun = true
 		}
 	}
@@ -606,7 +2242,7 @@ func EditUserAdminView() {
 
 	var n int = 1
 	for i := 0; i < nUser; i++ {
-		fmt.Printf("%d. ID: %d, Username: %s\n", n, usersData[i].id, usersData[i].username)
+		fmt.Printf("%d. ID: %d, Username: %s, Status: %s\n", n, usersData[i].id, usersData[i].username, userStatusLabel(usersData[i]))
 		n++
 	}
 
@@ -653,7 +2289,7 @@ func HapusUserAdminView() {
 
 	var n int = 1
 	for i := 0; i < nUser; i++ {
-		fmt.Printf("%d. ID: %d, Username: %s\n", n, usersData[i].id, usersData[i].username)
+		fmt.Printf("%d. ID: %d, Username: %s, Status: %s\n", n, usersData[i].id, usersData[i].username, userStatusLabel(usersData[i]))
 		n++
 	}
 
@@ -683,726 +2319,6943 @@ func HapusUserAdminView() {
 // LihatGrafikView displays statistics and analytics for the sentiment analysis system.
 // It renders a navigation breadcrumb showing the current location in the application
 // and prints a formatted LIHAT GRAFIK (View Graph/Statistics) title header.
+// The comment statistics can be narrowed to one platform and/or post.
 func LihatGrafikView() {
-	PrintBreadcrumbs([255]string{"Admin Menu", "Lihat Grafik"}, 2)
-	PrintTitle("LIHAT GRAFIK")
-	fmt.Println("Jumlah User:", nUser)
-	fmt.Println("Jumlah Komentar:", nComment)
-	fmt.Println("Jumlah Komentar Positif:", CountCommentsByCategory("positif"))
-	fmt.Println("Jumlah Komentar Netral:", CountCommentsByCategory("netral"))
-	fmt.Println("Jumlah Komentar Negatif:", CountCommentsByCategory("negatif"))
-	fmt.Scan()
-}
-
-// Form
+	var input int
+	var sumber CommentSource
 
-// LoginForm prompts the user to enter their username and password.
-// It reads the inputs from standard input and validates that neither field is empty.
-func LoginForm(username, password *string) error {
-	fmt.Print("Masukkan Username: ")
-	_, err := fmt.Scan(username)
-	if err != nil {
-		return err
-	}
+	for {
+		PrintBreadcrumbs([255]string{"Admin Menu", "Lihat Grafik"}, 2)
+		PrintTitle("LIHAT GRAFIK")
+		if sumber != (CommentSource{}) {
+			fmt.Println("Sumber:", sourceLabel(sumber))
+		}
+		fmt.Println("Jumlah User:", nUser)
+		fmt.Println("Jumlah Komentar:", CountCommentsBySource(sumber))
+		fmt.Println("Jumlah Komentar Positif:", CountCommentsByCategory("positif", sumber))
+		fmt.Println("Jumlah Komentar Netral:", CountCommentsByCategory("netral", sumber))
+		fmt.Println("Jumlah Komentar Negatif:", CountCommentsByCategory("negatif", sumber))
+		fmt.Println("Jumlah Komentar Toksik:", CountToxicComments(sumber))
+		for i := 0; i < 4; i++ {
+			fmt.Printf("Jumlah Komentar Berstatus %s: %d\n", statusList[i], CountCommentsByStatus(statusList[i], sumber))
+		}
 
-	fmt.Print("Masukkan Password: ")
-	_, err = fmt.Scan(password)
-	if err != nil {
-		return err
-	}
+		var sourceStats [NMAX]SourceStats
+		var nSourceStats int
 
-	if *username == "" || *password == "" {
-		return fmt.Errorf("username dan password tidak boleh kosong")
-	}
+		GetSourceStats(sumber, &sourceStats, &nSourceStats)
+		if nSourceStats > 0 {
+			fmt.Printf("\n%-16s%-16s%10s%10s%10s%10s\n", "Platform", "Post", "Positif", "Netral", "Negatif", "Toksik")
+			for i := 0; i < nSourceStats; i++ {
+				platform := sourceStats[i].platform
+				if platform == "" {
+					platform = "(aplikasi)"
+				}
+				fmt.Printf("%-16s%-16s%10d%10d%10d%10d\n", platform, sourceStats[i].postId,
+					sourceStats[i].perKategori[0], sourceStats[i].perKategori[1], sourceStats[i].perKategori[2], sourceStats[i].toksik)
+			}
+		}
 
-	return nil
-}
+		if nAspect > 0 {
+			fmt.Printf("\n%-16s%10s%10s%10s\n", "Aspek", "Positif", "Netral", "Negatif")
+			for a := 0; a < nAspect; a++ {
+				fmt.Printf("%-16s%10d%10d%10d\n", aspects[a].nama,
+					CountAspectByCategory(aspects[a].id, "positif", sumber),
+					CountAspectByCategory(aspects[a].id, "netral", sumber),
+					CountAspectByCategory(aspects[a].id, "negatif", sumber))
+			}
+		}
 
-// RegisterForm prompts the user to enter a username, password, and password confirmation.
-// It reads the inputs from standard input and validates that no field is empty
-// and that the password matches the confirmation password.
-func RegisterForm(username, password *string, editMode bool) error {
-	var confirmPassword string
+		err := PrintMenu("Pilih Menu", [255]string{"Filter Sumber", "Hapus Filter Sumber", "Kembali"}, 3, &input)
+		if err != nil || input == 3 {
+			return
+		}
 
-	fmt.Print("Masukkan Username: ")
-	_, err := fmt.Scan(username)
-	if err != nil {
-		return err
+		switch input {
+		case 1:
+			if err := SumberStatistikForm(&sumber); err != nil {
+				fmt.Println(err.Error())
+			}
+		case 2:
+			sumber = CommentSource{}
+		}
 	}
+}
 
-	fmt.Print("Masukkan Password: ")
-	_, err = fmt.Scan(password)
-	if err != nil {
-		return err
-	}
+// KelolaAspekView displays the aspect management interface for administrators.
+// It lists the defined aspects with their trigger keywords and lets the administrator
+// add, change, or remove aspects.
+func KelolaAspekView() {
+	var input int
 
-	fmt.Print("Masukkan Konfirmasi Password: ")
-	_, err = fmt.Scan(&confirmPassword)
-	if err != nil {
-		return err
-	}
+	for {
+		var aspectsData [NASPEK]Aspect
 
-	if !editMode && (*username == "" || *password == "" || confirmPassword == "") {
-		return fmt.Errorf("username, password, dan konfirmasi password tidak boleh kosong")
-	}
+		PrintBreadcrumbs([255]string{"Admin Menu", "Kelola Aspek"}, 2)
+		PrintTitle("KELOLA ASPEK")
 
-	if *password != confirmPassword {
-		return fmt.Errorf("password dan konfirmasi password tidak cocok")
-	}
+		if err := GetAspects(&aspectsData); err != nil {
+			fmt.Println(err.Error())
+		}
 
-	return nil
-}
+		for i := 0; i < nAspect; i++ {
+			fmt.Printf("%d. ID: %d, Nama: %s, Kata Kunci: %s\n", i+1, aspectsData[i].id, aspectsData[i].nama, joinWords(aspectsData[i].kataKunci, aspectsData[i].nKata))
+		}
 
-// KomentarForm prompts the user to enter comment text and a sentiment category.
-// It reads the inputs from standard input and validates them according to application rules.
-func KomentarForm(komentar, kategori *string, editMode bool) error {
-	fmt.Print("Masukkan Komentar: ")
-	_, err := fmt.Scan(komentar)
-	if err != nil {
-		return err
-	}
+		err := PrintMenu("Pilih Menu", [255]string{"Tambah Aspek", "Ubah Aspek", "Hapus Aspek", "Kembali"}, 4, &input)
+		if err != nil {
+			return
+		}
 
-	fmt.Print("Masukkan Kategori: ")
-	_, err = fmt.Scan(kategori)
-	if err != nil {
-		return err
-	}
+		if input == 4 {
+			break
+		}
 
-	if !editMode && (*komentar == "" || *kategori == "") {
-		return fmt.Errorf("komentar dan kategori tidak boleh kosong")
-	}
+		var nama string
+		var kataKunci [NKATAASPEK]string
+		var nKata, inputId int
+		var aspect Aspect
 
-	if *kategori != "" && *kategori != "positif" && *kategori != "negatif" && *kategori != "netral" {
-		return fmt.Errorf("kategori harus 'positif', 'negatif', atau 'netral'")
+		switch input {
+		case 1:
+			if err := AspekForm(&nama, &kataKunci, &nKata); err != nil {
+				fmt.Println(err.Error())
+			} else if err := CreateAspect(nama, kataKunci, nKata); err != nil {
+				fmt.Println(err.Error())
+			} else {
+				fmt.Println("Aspek berhasil ditambahkan!")
+			}
+		case 2:
+			fmt.Print("ID: ")
+			_, err := fmt.Scan(&inputId)
+			if err != nil {
+				fmt.Println(err.Error())
+			} else if err := FindAspectById(inputId, &aspect); err != nil {
+				fmt.Println(err.Error())
+			} else if err := AspekForm(&nama, &kataKunci, &nKata); err != nil {
+				fmt.Println(err.Error())
+			} else if err := EditAspect(nama, kataKunci, nKata, aspect.id); err != nil {
+				fmt.Println(err.Error())
+			} else {
+				fmt.Println("Aspek berhasil diubah!")
+			}
+		case 3:
+			fmt.Print("ID: ")
+			_, err := fmt.Scan(&inputId)
+			if err != nil {
+				fmt.Println(err.Error())
+			} else if err := DeleteAspect(inputId); err != nil {
+				fmt.Println(err.Error())
+			} else {
+				fmt.Println("Aspek berhasil dihapus!")
+			}
+		}
+	}
+}
+
+// KelolaKataKasarView displays the toxicity detector configuration for administrators.
+// It lists the abusive words with their severity and lets the administrator add or remove words,
+// change the toxicity threshold, and turn masking in listings on or off.
+func KelolaKataKasarView() {
+	var input int
+
+	for {
+		PrintBreadcrumbs([255]string{"Admin Menu", "Kelola Kata Kasar"}, 2)
+		PrintTitle("KELOLA KATA KASAR")
+
+		for i := 0; i < nToxicWord; i++ {
+			fmt.Printf("%d. Kata: %s, Tingkat: %.2f\n", i+1, toxicWords[i].kata, toxicWords[i].tingkat)
+		}
+		fmt.Printf("Ambang toksisitas: %.2f\n", ambangToksisitas)
+		if sensorAktif {
+			fmt.Println("Sensor: aktif")
+		} else {
+			fmt.Println("Sensor: nonaktif")
+		}
+
+		err := PrintMenu("Pilih Menu", [255]string{"Tambah Kata", "Hapus Kata", "Atur Ambang Toksisitas", "Aktifkan/Nonaktifkan Sensor", "Kembali"}, 5, &input)
+		if err != nil {
+			return
+		}
+
+		if input == 5 {
+			break
+		}
+
+		var kata string
+		var tingkat float64
+
+		switch input {
+		case 1:
+			fmt.Print("Masukkan Kata: ")
+			_, err := fmt.Scan(&kata)
+			if err != nil {
+				fmt.Println(err.Error())
+				continue
+			}
+
+			fmt.Print("Masukkan Tingkat: ")
+			_, err = fmt.Scan(&tingkat)
+			if err != nil {
+				fmt.Println(err.Error())
+			} else if err := CreateToxicWord(kata, tingkat); err != nil {
+				fmt.Println(err.Error())
+			} else {
+				fmt.Println("Kata berhasil ditambahkan!")
+			}
+		case 2:
+			fmt.Print("Masukkan Kata: ")
+			_, err := fmt.Scan(&kata)
+			if err != nil {
+				fmt.Println(err.Error())
+			} else if err := DeleteToxicWord(kata); err != nil {
+				fmt.Println(err.Error())
+			} else {
+				fmt.Println("Kata berhasil dihapus!")
+			}
+		case 3:
+			fmt.Print("Masukkan ambang toksisitas baru: ")
+			_, err := fmt.Scan(&tingkat)
+			if err != nil {
+				fmt.Println(err.Error())
+			} else if err := SetToxicityThreshold(tingkat); err != nil {
+				fmt.Println(err.Error())
+			} else {
+				fmt.Println("Ambang toksisitas berhasil diubah!")
+			}
+		case 4:
+			sensorAktif = !sensorAktif
+		}
+	}
+}
+
+// KlasterDuplikatView displays the clusters of duplicate comments for administrators.
+// It also lets the administrator change the similarity threshold and the per-user rate limit.
+func KlasterDuplikatView() {
+	var input int
+
+	for {
+		var cluster [NMAX]int
+		var nCluster int
+
+		PrintBreadcrumbs([255]string{"Admin Menu", "Klaster Duplikat"}, 2)
+		PrintTitle("KLASTER DUPLIKAT")
+
+		GetDuplicateClusters(&cluster, &nCluster)
+		fmt.Printf("Ambang kemiripan: %.2f, batas komentar: %d per %s\n", ambangKemiripan, batasKomentar, jendelaBatas.String())
+
+		if nCluster == 0 {
+			fmt.Println("Tidak ada klaster duplikat.")
+		}
+
+		for c := 1; c <= nCluster; c++ {
+			fmt.Printf("Klaster %d:\n", c)
+			for i := 0; i < nComment; i++ {
+				if cluster[i] == c {
+					fmt.Printf("   - ID: %d, Penulis: %s, Komentar: %s%s\n", comments[i].id, authorLabel(comments[i]), displayKomentar(comments[i]), flagLabel(comments[i]))
+				}
+			}
+		}
+
+		err := PrintMenu("Pilih Menu", [255]string{"Atur Ambang Kemiripan", "Atur Batas Komentar", "Kembali"}, 3, &input)
+		if err != nil {
+			return
+		}
+
+		if input == 3 {
+			break
+		}
+
+		switch input {
+		case 1:
+			var ambang float64
+
+			fmt.Print("Masukkan ambang kemiripan baru (0-1): ")
+			_, err := fmt.Scan(&ambang)
+			if err != nil {
+				fmt.Println(err.Error())
+			} else if err := SetSimilarityThreshold(ambang); err != nil {
+				fmt.Println(err.Error())
+			} else {
+				fmt.Println("Ambang kemiripan berhasil diubah!")
+			}
+		case 2:
+			var batas, detik int
+
+			fmt.Print("Masukkan jumlah komentar maksimum: ")
+			_, err := fmt.Scan(&batas)
+			if err != nil {
+				fmt.Println(err.Error())
+				continue
+			}
+
+			fmt.Print("Masukkan jendela waktu (detik): ")
+			_, err = fmt.Scan(&detik)
+			if err != nil {
+				fmt.Println(err.Error())
+			} else if err := SetRateLimit(batas, time.Duration(detik)*time.Second); err != nil {
+				fmt.Println(err.Error())
+			} else {
+				fmt.Println("Batas komentar berhasil diubah!")
+			}
+		}
+	}
+}
+
+// PerluDitinjauView displays the review queue of comments whose predicted category has a
+// confidence below ambangKeyakinan and has not been confirmed by a moderator yet.
+// Moderators can walk through the queue one comment at a time, review a comment by ID,
+// or change the confidence threshold.
+func PerluDitinjauView() {
+	var input int
+
+	for {
+		var queue [NMAX]Comment
+		var nQueue int
+
+		PrintBreadcrumbs([255]string{"Admin Menu", "Perlu Ditinjau"}, 2)
+		PrintTitle("PERLU DITINJAU")
+
+		GetReviewQueue(&queue, &nQueue)
+		fmt.Printf("Ambang keyakinan: %.2f\n", ambangKeyakinan)
+		fmt.Printf("Jumlah komentar perlu ditinjau: %d\n", nQueue)
+
+		err := PrintMenu("Pilih Menu", [255]string{"Tinjau Antrian", "Tinjau Berdasarkan ID", "Atur Ambang Keyakinan", "Kembali"}, 4, &input)
+		if err != nil {
+			return
+		}
+
+		if input == 4 {
+			break
+		}
+
+		switch input {
+		case 1:
+			if nQueue == 0 {
+				fmt.Println("Tidak ada komentar yang perlu ditinjau.")
+				continue
+			}
+
+			for i := 0; i < nQueue; i++ {
+				fmt.Printf("\nKomentar %d dari %d\n", i+1, nQueue)
+				if err := TinjauKomentarForm(queue[i]); err != nil {
+					break
+				}
+			}
+		case 2:
+			var inputId int
+			var comment Comment
+
+			fmt.Print("ID: ")
+			_, err := fmt.Scan(&inputId)
+			if err != nil {
+				fmt.Println(err.Error())
+			} else if err := FindCommentById(inputId, &comment); err != nil {
+				fmt.Println(err.Error())
+			} else {
+				TinjauKomentarForm(comment)
+			}
+		case 3:
+			var ambang float64
+
+			fmt.Print("Masukkan ambang keyakinan baru (0-1): ")
+			_, err := fmt.Scan(&ambang)
+			if err != nil {
+				fmt.Println(err.Error())
+			} else if err := SetConfidenceThreshold(ambang); err != nil {
+				fmt.Println(err.Error())
+			} else {
+				fmt.Println("Ambang keyakinan berhasil diubah!")
+			}
+		}
+	}
+}
+
+// EvaluasiKlasifikasiView displays the classifier evaluation report for administrators.
+// It compares the lexicon analyzer against the stored labels, runs k-fold cross-validation
+// for the Naive Bayes model, and offers to export both reports as JSON.
+func EvaluasiKlasifikasiView() {
+	var lexiconReport, bayesReport EvaluationReport
+	var k int
+	var path string
+
+	PrintBreadcrumbs([255]string{"Admin Menu", "Evaluasi Klasifikasi"}, 2)
+	PrintTitle("EVALUASI KLASIFIKASI")
+
+	if err := EvaluateLexicon(&lexiconReport); err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	PrintEvaluationReport(lexiconReport)
+
+	for {
+		fmt.Print("Jumlah fold untuk validasi silang Naive Bayes (2-10): ")
+		_, err := fmt.Scan(&k)
+		if err != nil {
+			fmt.Println(err.Error())
+		} else if err := CrossValidateNaiveBayes(k, &bayesReport); err != nil {
+			fmt.Println(err.Error())
+		} else {
+			PrintEvaluationReport(bayesReport)
+			break
+		}
+
+		if err := ConfirmForm("Apakah Anda ingin mencoba lagi?"); err != nil {
+			bayesReport = EvaluationReport{}
+			break
+		}
+	}
+
+	if err := ConfirmForm("Apakah Anda ingin mengekspor laporan ke JSON?"); err != nil {
+		return
+	}
+
+	for {
+		fmt.Print("Nama file: ")
+		_, err := fmt.Scan(&path)
+		if err != nil {
+			fmt.Println(err.Error())
+		} else if err := ExportEvaluationJSON(path, [2]EvaluationReport{lexiconReport, bayesReport}, 2); err != nil {
+			fmt.Println(err.Error())
+		} else {
+			fmt.Println("Laporan berhasil diekspor ke", path)
+			break
+		}
+
+		if err := ConfirmForm("Apakah Anda ingin mencoba lagi?"); err != nil {
+			break
+		}
+	}
+}
+
+// BenchmarkView measures the search and sort algorithms on synthetic comments of the sizes chosen by
+// the administrator and shows the timings and operation counts, optionally exporting them as CSV.
+// The stored comments are not changed.
+func BenchmarkView() {
+	var ukuran [NBENCHSIZE]int
+	var nUkuran, ulangan, nHasil int
+	var hasil [NBENCH]BenchmarkResult
+	var path string
+
+	PrintBreadcrumbs([255]string{"Admin Menu", "Benchmark Algoritma"}, 2)
+	PrintTitle("BENCHMARK ALGORITMA")
+
+	for {
+		err := BenchmarkForm(&ukuran, &nUkuran, &ulangan)
+		if err == nil {
+			err = RunBenchmark(ukuran, nUkuran, ulangan, &hasil, &nHasil)
+		}
+		if err == nil {
+			break
+		}
+		fmt.Println(err.Error())
+
+		if err := ConfirmForm("Apakah Anda ingin mencoba lagi?"); err != nil {
+			return
+		}
+	}
+
+	PrintBenchmarkTable(hasil, nHasil)
+
+	if err := ConfirmForm("Apakah Anda ingin mengekspor hasil ke CSV?"); err != nil {
+		return
+	}
+
+	for {
+		fmt.Print("Nama file: ")
+		_, err := fmt.Scan(&path)
+		if err != nil {
+			fmt.Println(err.Error())
+		} else if err := ExportBenchmarkCSV(path, hasil, nHasil); err != nil {
+			fmt.Println(err.Error())
+		} else {
+			fmt.Println("Hasil berhasil diekspor ke", path)
+			break
+		}
+
+		if err := ConfirmForm("Apakah Anda ingin mencoba lagi?"); err != nil {
+			break
+		}
+	}
+}
+
+// Form
+
+// LoginForm prompts the user to enter their username and password.
+// It reads the inputs from standard input and validates that neither field is empty.
+func LoginForm(username, password *string) error {
+	fmt.Print("Masukkan Username: ")
+	_, err := fmt.Scan(username)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print("Masukkan Password: ")
+	_, err = fmt.Scan(password)
+	if err != nil {
+		return err
+	}
+
+	if *username == "" || *password == "" {
+		return fmt.Errorf("username dan password tidak boleh kosong")
+	}
+
+	return nil
+}
+
+// RegisterForm prompts the user to enter a username, password, and password confirmation.
+// It only checks that the password matches the confirmation; the username and password rules
+// are enforced by CreateUser and EditUser. In edit mode "-" leaves a field unchanged and is
+// returned as an empty string, and no confirmation is asked for an unchanged password.
+func RegisterForm(username, password *string, editMode bool) error {
+	var confirmPassword string
+
+	if editMode {
+		fmt.Println("Isi dengan - jika tidak ingin diubah.")
+	}
+
+	fmt.Print("Masukkan Username: ")
+	_, err := fmt.Scan(username)
+	if err != nil {
+		return err
+	}
+	if editMode && *username == "-" {
+		*username = ""
+	}
+
+	fmt.Print("Masukkan Password: ")
+	_, err = fmt.Scan(password)
+	if err != nil {
+		return err
+	}
+	if editMode && *password == "-" {
+		*password = ""
+		return nil
+	}
+
+	fmt.Print("Masukkan Konfirmasi Password: ")
+	_, err = fmt.Scan(&confirmPassword)
+	if err != nil {
+		return err
+	}
+
+	if *password != confirmPassword {
+		return fmt.Errorf("password dan konfirmasi password tidak cocok")
+	}
+
+	return nil
+}
+
+// PasswordPolicyForm prompts the administrator for each rule of the password policy.
+func PasswordPolicyForm(policy *PasswordPolicy) error {
+	fmt.Print("Panjang minimum password: ")
+	_, err := fmt.Scan(&policy.panjangMin)
+	if err != nil {
+		return err
+	}
+
+	policy.wajibHurufKecil = ConfirmForm("Wajib huruf kecil?") == nil
+	policy.wajibHurufBesar = ConfirmForm("Wajib huruf besar?") == nil
+	policy.wajibAngka = ConfirmForm("Wajib angka?") == nil
+	policy.wajibSimbol = ConfirmForm("Wajib simbol?") == nil
+	policy.tolakSamaUsername = ConfirmForm("Tolak password yang sama dengan username?") == nil
+
+	return nil
+}
+
+// KomentarForm prompts the user to enter comment text.
+// It reads the input from standard input and validates it according to application rules.
+// The sentiment category is assigned by the automatic classifier, not entered by the user.
+func KomentarForm(komentar *string, editMode bool) error {
+	fmt.Print("Masukkan Komentar: ")
+	_, err := fmt.Scan(komentar)
+	if err != nil {
+		return err
+	}
+
+	if !editMode && *komentar == "" {
+		return fmt.Errorf("komentar tidak boleh kosong")
+	}
+
+	return nil
+}
+
+// AspekForm prompts the administrator to enter an aspect name and its trigger keywords.
+// The keywords are entered as a single comma-separated list, for example "harga,price,mahal".
+func AspekForm(nama *string, kataKunci *[NKATAASPEK]string, nKata *int) error {
+	var daftar string
+
+	fmt.Print("Masukkan Nama Aspek: ")
+	_, err := fmt.Scan(nama)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print("Masukkan Kata Kunci (pisahkan dengan koma): ")
+	_, err = fmt.Scan(&daftar)
+	if err != nil {
+		return err
+	}
+
+	*nama = toLower(*nama)
+	splitWords(toLower(daftar), ',', kataKunci, nKata)
+
+	if *nama == "" || *nKata == 0 {
+		return fmt.Errorf("nama aspek dan kata kunci tidak boleh kosong")
+	}
+
+	return nil
+}
+
+// DuplikatForm checks whether the comment text is an exact or near duplicate of an existing comment.
+// When a duplicate is found it shows the existing comment and asks whether to reject the new comment (1),
+// create it flagged as a duplicate (2), or merge it into the existing comment (3). The chosen action is
+// stored in tindakan and the existing comment in original; tindakan stays 0 when there is no duplicate.
+func DuplikatForm(komentar string, original *Comment, tindakan *int) error {
+	var kemiripan float64
+
+	*tindakan = 0
+	*original = Comment{}
+
+	if !FindSimilarComment(komentar, original, &kemiripan) {
+		return nil
+	}
+
+	if kemiripan >= 1 {
+		fmt.Printf("Komentar identik dengan komentar ID %d: %s\n", original.id, displayKomentar(*original))
+	} else {
+		fmt.Printf("Komentar mirip dengan komentar ID %d (kemiripan %.2f): %s\n", original.id, kemiripan, displayKomentar(*original))
+	}
+
+	return PrintMenu("Pilih Tindakan", [255]string{"Tolak", "Buat dan Tandai sebagai Duplikat", "Gabungkan"}, 3, tindakan)
+}
+
+// StatusFilterForm prompts the administrator to choose which moderation status to show.
+// An empty status means that comments of every status are shown.
+func StatusFilterForm(status *string) error {
+	var input int
+
+	err := PrintMenu("Pilih Status", [255]string{"Semua", statusList[0], statusList[1], statusList[2], statusList[3]}, 5, &input)
+	if err != nil {
+		return err
+	}
+
+	if input == 1 {
+		*status = ""
+	} else {
+		*status = statusList[input-2]
+	}
+	return nil
+}
+
+// StatusForm prompts the administrator to choose one of the statuses allowed from the current status.
+func StatusForm(current string, status *string) error {
+	var input, n int
+	var menu [255]string
+
+	from := statusIndex(current)
+	if from == -1 {
+		return fmt.Errorf("status '%s' tidak dikenal", current)
+	}
+
+	for to := 0; to < 4; to++ {
+		if transisiStatus[from][to] {
+			menu[n] = statusList[to]
+			n++
+		}
+	}
+
+	fmt.Println("Status saat ini:", current)
+	err := PrintMenu("Pilih Status Baru", menu, n, &input)
+	if err != nil {
+		return err
+	}
+
+	*status = menu[input-1]
+	return nil
+}
+
+// KategoriForm prompts a moderator to choose one of the sentiment categories.
+func KategoriForm(kategori *string) error {
+	var input int
+
+	err := PrintMenu("Pilih Kategori", [255]string{kategoriList[0], kategoriList[1], kategoriList[2]}, 3, &input)
+	if err != nil {
+		return err
+	}
+
+	*kategori = kategoriList[input-1]
+	return nil
+}
+
+// TinjauKomentarForm shows a single comment with its predicted category and confidence and
+// asks the moderator to confirm or correct the label, or to skip the comment.
+// It returns an error when the moderator chooses to stop reviewing.
+func TinjauKomentarForm(comment Comment) error {
+	var input int
+	var kategori string
+
+	fmt.Printf("ID: %d, Penulis: %s, Komentar: %s\n", comment.id, authorLabel(comment), comment.komentar)
+	fmt.Printf("Prediksi: %s (keyakinan %.2f)\n", comment.kategori, comment.keyakinan)
+	if comment.kategoriKonfirmasi != "" {
+		fmt.Println("Dikonfirmasi sebagai:", comment.kategoriKonfirmasi)
+	}
+
+	err := PrintMenu("Pilih Tindakan", [255]string{"Konfirmasi Prediksi", "Koreksi Kategori", "Lewati", "Berhenti"}, 4, &input)
+	if err != nil {
+		return err
+	}
+
+	switch input {
+	case 1:
+		kategori = comment.kategori
+	case 2:
+		if err := KategoriForm(&kategori); err != nil {
+			return err
+		}
+	case 3:
+		return nil
+	case 4:
+		return fmt.Errorf("cancel")
+	}
+
+	if err := ConfirmCommentLabel(comment.id, kategori); err != nil {
+		fmt.Println(err.Error())
+		return nil
+	}
+
+	fmt.Println("Kategori berhasil dikonfirmasi!")
+	return nil
+}
+
+// QueryForm prompts for a comment search query until it parses without errors.
+// Syntax errors are shown with their position and the user may correct the query or give up.
+func QueryForm(search *string) error {
+	var query Query
+
+	fmt.Println("Gunakan AND, OR, NOT, (kurung), \"frasa\", wildcard * dan ?, kategori:, user: (ID atau username), status:, platform:, post:, handle:, dan len>N.")
+	for {
+		if err := ReadLine("Masukkan query pencarian komentar: ", search); err != nil {
+			return err
+		}
+
+		err := ParseQuery(*search, &query)
+		if err == nil {
+			return nil
+		}
+		fmt.Println("Query tidak valid,", err.Error())
+
+		if err := ConfirmForm("Apakah Anda ingin memperbaiki query?"); err != nil {
+			return err
+		}
+	}
+}
+
+// RegexForm prompts for a regular expression until it compiles without errors.
+// Errors are shown with their position and the user may correct the pattern or give up.
+func RegexForm(pola *string) error {
+	var re Regex
+
+	fmt.Println("Didukung: . [abc] [^a-z] \\d \\w \\s * + ? {m,n} | ( ) ^ $, awali dengan (?i) untuk mengabaikan huruf besar/kecil.")
+	for {
+		if err := ReadLine("Masukkan pola regex: ", pola); err != nil {
+			return err
+		}
+
+		err := CompileRegex(*pola, &re)
+		if err == nil {
+			return nil
+		}
+		fmt.Println("Pola tidak valid,", err.Error())
+
+		if err := ConfirmForm("Apakah Anda ingin memperbaiki pola?"); err != nil {
+			return err
+		}
+	}
+}
+
+// FuzzyForm prompts for the keywords of a fuzzy search and the maximum number of typos allowed per word.
+func FuzzyForm(search *string) error {
+	var jarak int
+
+	if err := ReadLine("Masukkan kata kunci: ", search); err != nil {
+		return err
+	}
+
+	fmt.Printf("Jarak maksimum salah ketik per kata (0-%d, saat ini %d): ", jarakFuzzyBatas, jarakFuzzyMaks)
+	_, err := fmt.Scan(&jarak)
+	if err != nil {
+		return err
+	}
+
+	return SetFuzzyDistance(jarak)
+}
+
+// SumberForm prompts for the source fields of a comment. Entering "-" leaves a field empty.
+func SumberForm(sumber *CommentSource) error {
+	var fields [4]*string = [4]*string{&sumber.platform, &sumber.postId, &sumber.idEksternal, &sumber.handle}
+	var labels [4]string = [4]string{"Platform", "ID Post", "ID Komentar Eksternal", "Handle Penulis"}
+
+	fmt.Println("Isi dengan - untuk mengosongkan.")
+	for i := 0; i < 4; i++ {
+		fmt.Printf("%s: ", labels[i])
+		_, err := fmt.Scan(fields[i])
+		if err != nil {
+			return err
+		}
+
+		if *fields[i] == "-" {
+			*fields[i] = ""
+		}
+	}
+
+	return nil
+}
+
+// SumberStatistikForm prompts for the platform and post the statistics are narrowed to.
+func SumberStatistikForm(sumber *CommentSource) error {
+	var fields [2]*string = [2]*string{&sumber.platform, &sumber.postId}
+	var labels [2]string = [2]string{"Platform", "ID Post"}
+
+	fmt.Println("Isi dengan - untuk semua.")
+	for i := 0; i < 2; i++ {
+		fmt.Printf("%s: ", labels[i])
+		_, err := fmt.Scan(fields[i])
+		if err != nil {
+			return err
+		}
+
+		if *fields[i] == "-" {
+			*fields[i] = ""
+		}
+	}
+
+	return nil
+}
+
+// SortForm prompts for the sort keys with their direction, up to NSORTKEY keys, and the algorithm.
+// The first key is required, the secondary keys are optional.
+func SortForm(spec *SortSpec, kunci []string) error {
+	var menu [255]string
+	var input int
+
+	*spec = SortSpec{}
+	for i := 0; i < len(kunci); i++ {
+		menu[i] = kunci[i]
+	}
+
+	for spec.nKunci < NSORTKEY {
+		title := "Urutkan Berdasarkan"
+		if spec.nKunci > 0 {
+			title = "Kunci Sekunder"
+		}
+
+		if err := PrintMenu(title, menu, len(kunci), &input); err != nil {
+			return err
+		}
+		spec.kunci[spec.nKunci] = kunci[input-1]
+
+		if err := PrintMenu("Pilih Urutan", [255]string{"Ascending (A-Z)", "Descending (Z-A)"}, 2, &input); err != nil {
+			return err
+		}
+		spec.menurun[spec.nKunci] = input == 2
+		spec.nKunci++
+
+		if spec.nKunci == NSORTKEY || ConfirmForm("Tambah kunci sekunder?") != nil {
+			break
+		}
+	}
+
+	if err := PrintMenu("Pilih Algoritma", [255]string{"Selection Sort", "Insertion Sort"}, 2, &input); err != nil {
+		return err
+	}
+	spec.algoritma = algoritmaSort[input-1]
+
+	return nil
+}
+
+// BenchmarkForm prompts for the sizes of the synthetic data sets, separated by spaces,
+// and the number of repetitions of each algorithm.
+func BenchmarkForm(ukuran *[NBENCHSIZE]int, nUkuran, ulangan *int) error {
+	var line string
+	var words [NKATAASPEK]string
+	var nWord int
+
+	if err := ReadLine(fmt.Sprintf("Ukuran data, pisahkan dengan spasi (maksimal %d): ", NMAX), &line); err != nil {
+		return err
+	}
+
+	splitWords(line, ' ', &words, &nWord)
+	*nUkuran = 0
+	for i := 0; i < nWord; i++ {
+		nilai, ok := parseNumber(words[i])
+		if !ok {
+			return fmt.Errorf("ukuran '%s' bukan angka", words[i])
+		}
+		if *nUkuran >= NBENCHSIZE {
+			return fmt.Errorf("maksimal %d ukuran data", NBENCHSIZE)
+		}
+		ukuran[*nUkuran] = nilai
+		*nUkuran++
+	}
+
+	fmt.Print("Jumlah pengulangan: ")
+	_, err := fmt.Scan(ulangan)
+	return err
+}
+
+// HalamanForm lets the user move to the next, previous, or a chosen page of a listing with
+// total entries, or change the number of entries per page.
+func HalamanForm(page *Pagination, total int) error {
+	var input, nilai int
+
+	err := PrintMenu("Halaman", [255]string{"Halaman Berikutnya", "Halaman Sebelumnya", "Lompat ke Halaman", "Ubah Ukuran Halaman", "Kembali"}, 5, &input)
+	if err != nil {
+		return err
+	}
+
+	switch input {
+	case 1:
+		return NextPage(page, total)
+	case 2:
+		return PrevPage(page, total)
+	case 3:
+		fmt.Printf("Nomor halaman (1-%d): ", pageCount(total, page.ukuran))
+		if _, err := fmt.Scan(&nilai); err != nil {
+			return err
+		}
+		return GoToPage(page, nilai, total)
+	case 4:
+		fmt.Printf("Jumlah per halaman (1-%d, saat ini %d): ", NMAX, page.ukuran)
+		if _, err := fmt.Scan(&nilai); err != nil {
+			return err
+		}
+		return SetPageSize(page, nilai)
+	}
+
+	return fmt.Errorf("cancel")
+}
+
+// TanggalForm prompts for a date in the DD-MM-YYYY format. Entering "-" leaves the date empty.
+func TanggalForm(label string, tanggal *time.Time) error {
+	var input string
+
+	fmt.Printf("%s (DD-MM-YYYY, - untuk tanpa batas): ", label)
+	if _, err := fmt.Scan(&input); err != nil {
+		return err
+	}
+
+	if input == "-" {
+		*tanggal = time.Time{}
+		return nil
+	}
+
+	t, err := time.ParseInLocation("02-01-2006", input, time.Local)
+	if err != nil {
+		return fmt.Errorf("tanggal '%s' tidak valid, gunakan format DD-MM-YYYY", input)
+	}
+
+	*tanggal = t
+	return nil
+}
+
+// TandaFilterForm lets the user switch the moderation flags of a filter on and off until they are done.
+func TandaFilterForm(tanda *[4]bool) {
+	var input int
+
+	for {
+		var menu [255]string
+
+		for i := 0; i < 4; i++ {
+			if tanda[i] {
+				menu[i] = "[x] " + tandaList[i]
+			} else {
+				menu[i] = "[ ] " + tandaList[i]
+			}
+		}
+		menu[4] = "Selesai"
+
+		if err := PrintMenu("Tanda Moderasi", menu, 5, &input); err != nil || input == 5 {
+			return
+		}
+
+		tanda[input-1] = !tanda[input-1]
+	}
+}
+
+// UbahPasswordForm prompts the user for the current password, the new password, and its confirmation.
+// It validates that the new password matches the confirmation.
+func UbahPasswordForm(passwordLama, passwordBaru *string) error {
+	var confirmPassword string
+
+	fmt.Print("Masukkan Password Saat Ini: ")
+	_, err := fmt.Scan(passwordLama)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print("Masukkan Password Baru: ")
+	_, err = fmt.Scan(passwordBaru)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print("Masukkan Konfirmasi Password Baru: ")
+	_, err = fmt.Scan(&confirmPassword)
+	if err != nil {
+		return err
+	}
+
+	if *passwordBaru != confirmPassword {
+		return fmt.Errorf("password baru dan konfirmasi password tidak cocok")
+	}
+
+	return nil
+}
+
+// HapusAkunForm asks the user what should happen to their comments and confirms the deletion.
+// hapusKomentar is set to true when the comments are deleted and false when they are kept anonymously.
+func HapusAkunForm(hapusKomentar *bool) error {
+	var input int
+
+	err := PrintMenu("Komentar Anda", [255]string{"Hapus semua komentar", "Pertahankan sebagai anonim", "Batal"}, 3, &input)
+	if err != nil {
+		return err
+	}
+
+	if input == 3 {
+		return fmt.Errorf("penghapusan akun dibatalkan")
+	}
+
+	*hapusKomentar = input == 1
+
+	if err := ConfirmForm("Yakin ingin menghapus akun ini?"); err != nil {
+		return fmt.Errorf("penghapusan akun dibatalkan")
+	}
+
+	return nil
+}
+
+// ConfirmForm prompts the user with a yes/no question and returns the result.
+// It displays the provided title followed by options for Yes (1) or No (2),
+// then reads the user's selection from standard input.
+func ConfirmForm(title string) error {
+	var input int
+
+	for {
+		fmt.Printf("%s (1. Ya, 2. Tidak): ", title)
+		_, err := fmt.Scan(&input)
+		if err != nil {
+			return err
+		}
+
+		if input == 1 {
+			return nil
+		} else if input == 2 {
+			return fmt.Errorf("cancel")
+		} else {
+			fmt.Println("Pilihan tidak valid, silakan pilih 1 atau 2.")
+		}
+	}
+}
+
+// Data
+
+// GetUsers retrieves all registered users from the system and copies them to the provided array.
+func GetUsers(usersInput *[NMAX]User) error {
+	if nUser == 0 {
+		return fmt.Errorf("tidak ada pengguna yang terdaftar")
+	}
+
+	*usersInput = users
+
+	return nil
+}
+
+// GetUsersSearch searches for users whose usernames contain the specified substring.
+// It performs a case-insensitive search by converting both the search term and
+// usernames to lowercase before comparison.
+func GetUsersSearch(usersInput *[NMAX]User, search string) error {
+	var matchCount int
+	var isMatch bool
+
+	if nUser == 0 {
+		return fmt.Errorf("tidak ada pengguna yang terdaftar")
+	}
+
+	var tempUsers [NMAX]User
+	matchCount = 0
+
+	search = toLower(search)
+
+	for i := 0; i < nUser; i++ {
+		userLower := toLower(users[i].username)
+		isMatch = false
+
+		for j := 0; j <= len(userLower)-len(search); j++ {
+			isMatch = true
+
+			for k := 0; k < len(search); k++ {
+				if userLower[j+k] != search[k] {
+					isMatch = false
+					break
+				}
+			}
+
+			if isMatch {
+				tempUsers[matchCount] = users[i]
+				matchCount++
+				break
+			}
+		}
+	}
+
+	if matchCount == 0 {
+		return fmt.Errorf("tidak ada username yang sesuai dengan pencarian")
+	}
+
+	for i := 0; i < NMAX; i++ {
+		if i < matchCount {
+			usersInput[i] = tempUsers[i]
+		} else {
+			usersInput[i] = User{}
+		}
+	}
+
+	return nil
+}
+
+// GetUsersFuzzy searches for users whose username is within jarakFuzzyMaks edits of the search term,
+// ignoring case. The results are ranked by edit distance, closest first; users with the same
+// distance keep their order by ID.
+func GetUsersFuzzy(usersInput *[NMAX]User, search string) error {
+	var tempUsers [NMAX]User
+	var jarak [NMAX]int
+	var matchCount int
+
+	if nUser == 0 {
+		return fmt.Errorf("tidak ada pengguna yang terdaftar")
+	}
+
+	search = toLower(search)
+
+	for i := 0; i < nUser; i++ {
+		d := DamerauLevenshtein(toLower(users[i].username), search)
+		if d > jarakFuzzyMaks {
+			continue
+		}
+
+		j := matchCount - 1
+		for j >= 0 && jarak[j] > d {
+			tempUsers[j+1] = tempUsers[j]
+			jarak[j+1] = jarak[j]
+			j--
+		}
+		tempUsers[j+1] = users[i]
+		jarak[j+1] = d
+		matchCount++
+	}
+
+	if matchCount == 0 {
+		return fmt.Errorf("tidak ada username yang mirip dengan '%s'", search)
+	}
+
+	for i := 0; i < NMAX; i++ {
+		if i < matchCount {
+			usersInput[i] = tempUsers[i]
+		} else {
+			usersInput[i] = User{}
+		}
+	}
+
+	return nil
+}
+
+// GetUsersSort sorts the users shown in the listing, the entries of usersInput up to the first empty one,
+// in place using the keys, directions and algorithm of spec. The work done is counted in stats.
+func GetUsersSort(usersInput *[NMAX]User, spec SortSpec, stats *SortStats) error {
+	var n int
+	var key User
+	var jumlah [NMAX]int
+	var keyJumlah int
+
+	for n < NMAX && usersInput[n].id != 0 {
+		n++
+	}
+	if n == 0 {
+		return fmt.Errorf("tidak ada user yang tersedia")
+	}
+
+	if err := ValidateSortSpec(spec, kunciSortUser[:]); err != nil {
+		return err
+	}
+
+	// The comment counts are computed once and move together with their users.
+	for i := 0; i < n; i++ {
+		jumlah[i] = countCommentsByUser(usersInput[i].id)
+	}
+
+	*stats = SortStats{}
+
+	if spec.algoritma == "selection" {
+		for i := 0; i < n-1; i++ {
+			minIdx := i
+			for j := i + 1; j < n; j++ {
+				if compareUsers(usersInput[j], usersInput[minIdx], jumlah[j], jumlah[minIdx], spec, stats) < 0 {
+					minIdx = j
+				}
+			}
+
+			if minIdx != i {
+				usersInput[i], usersInput[minIdx] = usersInput[minIdx], usersInput[i]
+				jumlah[i], jumlah[minIdx] = jumlah[minIdx], jumlah[i]
+				stats.pertukaran++
+			}
+		}
+	} else {
+		for i := 1; i < n; i++ {
+			key = usersInput[i]
+			keyJumlah = jumlah[i]
+			j := i - 1
+
+			for j >= 0 && compareUsers(usersInput[j], key, jumlah[j], keyJumlah, spec, stats) > 0 {
+				usersInput[j+1] = usersInput[j]
+				jumlah[j+1] = jumlah[j]
+				stats.pertukaran++
+				j--
+			}
+
+			usersInput[j+1] = key
+			jumlah[j+1] = keyJumlah
+		}
+	}
+
+	return nil
+}
+
+// compareUsers compares two users on the keys of spec in order of priority and counts the comparison.
+// jumlahA and jumlahB are the numbers of comments written by a and b.
+// It returns a negative number when a comes first, a positive number when b comes first, and 0 when
+// they are the same user.
+func compareUsers(a, b User, jumlahA, jumlahB int, spec SortSpec, stats *SortStats) int {
+	stats.perbandingan++
+
+	for k := 0; k < spec.nKunci; k++ {
+		var hasil int
+
+		switch spec.kunci[k] {
+		case "id":
+			hasil = a.id - b.id
+		case "username":
+			hasil = compareText(toLower(a.username), toLower(b.username))
+		case "tanggal":
+			hasil = a.dibuat.Compare(b.dibuat)
+		case "jumlah komentar":
+			hasil = jumlahA - jumlahB
+		}
+
+		if spec.menurun[k] {
+			hasil = -hasil
+		}
+		if hasil != 0 {
+			return hasil
+		}
+	}
+
+	return a.id - b.id
+}
+
+// FindUserByUsername searches for a user with the specified username in the users array.
+// Usernames are compared case-insensitively.
+// If found, it copies the user data to the provided user pointer.
+func FindUserByUsername(username string, user *User) error {
+	username = toLower(username)
+
+	for i := 0; i < nUser; i++ {
+		if toLower(users[i].username) == username {
+			*user = users[i]
+			return nil
+		}
+	}
+	return fmt.Errorf("pengguna dengan username '%s' tidak ditemukan", username)
+}
+
+// FindUserById searches for a user with the specified ID using binary search algorithm.
+// It assumes that the global users array is sorted by ID in ascending order.
+// If found, it copies the user data to the provided user pointer.
+func FindUserById(userId int, user *User) error {
+	var left, right, mid int
+
+	left = 0
+	right = nUser - 1
+
+	for left <= right {
+		mid = (left + right) / 2
+
+		if users[mid].id == userId {
+			*user = users[mid]
+			return nil
+		}
+
+		if users[mid].id < userId {
+			left = mid + 1
+		} else {
+			right = mid - 1
+		}
+	}
+	return fmt.Errorf("pengguna dengan ID %d tidak ditemukan", userId)
+}
+
+// CreateUser creates a new user with the specified username and password.
+// The username and password are checked against the username rules and kebijakanPassword.
+// It adds the user to the users array and assigns a unique ID.
+func CreateUser(username, password string) error {
+	if nUser >= NMAX {
+		return fmt.Errorf("jumlah pengguna sudah mencapai batas maksimum")
+	}
+
+	if err := ValidateUsername(username); err != nil {
+		return err
+	}
+
+	if err := checkUsernameAvailable(username, 0); err != nil {
+		return err
+	}
+
+	if err := ValidatePassword(password, username); err != nil {
+		return err
+	}
+
+	users[nUser] = User{
+		id:       idUser,
+		username: username,
+		password: password,
+		status:   "active",
+		dibuat:   time.Now(),
+	}
+	nUser++
+	idUser++
+	return nil
+}
+
+// EditUser updates a user's username and/or password using binary search to find the user.
+// The new values are checked against the username rules and kebijakanPassword.
+// It assumes that the users array is sorted by ID in ascending order.
+func EditUser(username, password string, userId int) error {
+	var left, right, mid int
+
+	left = 0
+	right = nUser - 1
+
+	for left <= right {
+		mid = (left + right) / 2
+
+		if users[mid].id == userId {
+			if username != "" {
+				if err := ValidateUsername(username); err != nil {
+					return err
+				}
+				if err := checkUsernameAvailable(username, userId); err != nil {
+					return err
+				}
+			}
+			if password != "" {
+				newUsername := users[mid].username
+				if username != "" {
+					newUsername = username
+				}
+				if err := ValidatePassword(password, newUsername); err != nil {
+					return err
+				}
+			}
+
+			if username != "" {
+				users[mid].username = username
+			}
+			if password != "" {
+				users[mid].password = password
+			}
+			return nil
+		}
+
+		if users[mid].id < userId {
+			left = mid + 1
+		} else {
+			right = mid - 1
+		}
+	}
+	return fmt.Errorf("pengguna dengan ID %d tidak ditemukan", userId)
+}
+
+// ValidateUsername checks that a username has an allowed length and contains only
+// letters, digits, dots, and underscores.
+func ValidateUsername(username string) error {
+	if len(username) < panjangUsernameMin || len(username) > panjangUsernameMaks {
+		return fmt.Errorf("username harus terdiri dari %d sampai %d karakter", panjangUsernameMin, panjangUsernameMaks)
+	}
+
+	for i := 0; i < len(username); i++ {
+		c := username[i]
+		if !((c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '.' || c == '_') {
+			return fmt.Errorf("username hanya boleh berisi huruf, angka, titik (.), dan garis bawah (_)")
+		}
+	}
+
+	return nil
+}
+
+// ValidatePassword checks a password against every rule of kebijakanPassword and returns
+// an error naming the first rule that is not satisfied.
+func ValidatePassword(password, username string) error {
+	var hasLower, hasUpper, hasDigit, hasSymbol bool
+
+	if len([]rune(password)) < kebijakanPassword.panjangMin {
+		return fmt.Errorf("password minimal %d karakter", kebijakanPassword.panjangMin)
+	}
+
+	for _, c := range password {
+		if c >= 'a' && c <= 'z' {
+			hasLower = true
+		} else if c >= 'A' && c <= 'Z' {
+			hasUpper = true
+		} else if c >= '0' && c <= '9' {
+			hasDigit = true
+		} else {
+			hasSymbol = true
+		}
+	}
+
+	if kebijakanPassword.wajibHurufKecil && !hasLower {
+		return fmt.Errorf("password harus mengandung huruf kecil")
+	}
+	if kebijakanPassword.wajibHurufBesar && !hasUpper {
+		return fmt.Errorf("password harus mengandung huruf besar")
+	}
+	if kebijakanPassword.wajibAngka && !hasDigit {
+		return fmt.Errorf("password harus mengandung angka")
+	}
+	if kebijakanPassword.wajibSimbol && !hasSymbol {
+		return fmt.Errorf("password harus mengandung simbol")
+	}
+	if kebijakanPassword.tolakSamaUsername && toLower(password) == toLower(username) {
+		return fmt.Errorf("password tidak boleh sama dengan username")
+	}
+
+	return nil
+}
+
+// checkUsernameAvailable returns an error when another user already has the username,
+// comparing case-insensitively. The user with the given ID is ignored so a user can keep their own name.
+func checkUsernameAvailable(username string, userId int) error {
+	for i := 0; i < nUser; i++ {
+		if users[i].id != userId && toLower(users[i].username) == toLower(username) {
+			return fmt.Errorf("username '%s' sudah terdaftar sebagai '%s'", username, users[i].username)
+		}
+	}
+	return nil
+}
+
+// SetPasswordPolicy replaces the password policy after checking that the minimum length is sensible.
+func SetPasswordPolicy(policy PasswordPolicy) error {
+	if policy.panjangMin < 1 {
+		return fmt.Errorf("panjang minimum password harus lebih dari 0")
+	}
+
+	kebijakanPassword = policy
+	return nil
+}
+
+// DeleteUser removes a user with the specified ID from the users array using binary search.
+// It assumes that the users array is sorted by ID in ascending order.
+// Once found, it deletes the user by shifting all subsequent elements one
+// position to the left to fill the gap, decrements the user counter, and removes the filters the user saved.
+func DeleteUser(userId int) error {
+	var left, right, mid int
+	left = 0
+	right = nUser - 1
+
+	for left <= right {
+		mid = (left + right) / 2
+
+		if users[mid].id == userId {
+			for j := mid; j < nUser-1; j++ {
+				users[j] = users[j+1]
+			}
+			users[nUser-1] = User{}
+			nUser--
+			DeleteSavedFilters(userId)
+			return nil
+		}
+
+		if users[mid].id < userId {
+			left = mid + 1
+		} else {
+			right = mid - 1
+		}
+	}
+	return fmt.Errorf("pengguna dengan ID %d tidak ditemukan", userId)
+}
+
+// ChangePassword changes the password of a user after checking that passwordLama matches
+// the current password. The new password must satisfy kebijakanPassword and differ from the current one.
+func ChangePassword(userId int, passwordLama, passwordBaru string) error {
+	var user User
+
+	if err := FindUserById(userId, &user); err != nil {
+		return err
+	}
+
+	if user.password != passwordLama {
+		return fmt.Errorf("password saat ini salah")
+	}
+
+	if passwordBaru == passwordLama {
+		return fmt.Errorf("password baru tidak boleh sama dengan password saat ini")
+	}
+
+	return EditUser("", passwordBaru, userId)
+}
+
+// DeleteOwnAccount deletes a user account. When hapusKomentar is true all comments of the user
+// are deleted, otherwise they are kept and detached from the account by setting their user ID to userDihapusId.
+func DeleteOwnAccount(userId int, hapusKomentar bool) error {
+	var user User
+
+	if err := FindUserById(userId, &user); err != nil {
+		return err
+	}
+
+	for i := nComment - 1; i >= 0; i-- {
+		if comments[i].userId == userId {
+			if hapusKomentar {
+				DeleteComment(comments[i].id)
+			} else {
+				comments[i].userId = userDihapusId
+			}
+		}
+	}
+
+	return DeleteUser(userId)
+}
+
+// GetUserCommentStats counts the comments of a user per category and status,
+// along with the number of toxic comments and comments marked as duplicates.
+func GetUserCommentStats(userId int, stats *UserCommentStats) {
+	*stats = UserCommentStats{}
+
+	for i := 0; i < nComment; i++ {
+		if comments[i].userId != userId {
+			continue
+		}
+
+		stats.total++
+
+		k := kategoriIndex(effectiveKategori(comments[i]))
+		if k != -1 {
+			stats.perKategori[k]++
+		}
+
+		s := statusIndex(comments[i].status)
+		if s != -1 {
+			stats.perStatus[s]++
+		}
+
+		if comments[i].toksik {
+			stats.toksik++
+		}
+		if comments[i].duplikatDari != 0 {
+			stats.duplikat++
+		}
+	}
+}
+
+// StartSession starts a new session for the user and returns its ID.
+// Sessions that are already expired are removed first to make room.
+func StartSession(userId int) (int, error) {
+	now := time.Now()
+
+	for i := nSession - 1; i >= 0; i-- {
+		if sessionExpired(sessions[i], now) {
+			EndSession(sessions[i].id)
+		}
+	}
+
+	if nSession >= NMAX {
+		return 0, fmt.Errorf("jumlah sesi aktif sudah mencapai batas maksimum")
+	}
+
+	sessions[nSession] = Session{
+		id:            idSession,
+		userId:        userId,
+		dibuat:        now,
+		terakhirAktif: now,
+		kedaluwarsa:   now.Add(durasiSesi),
+	}
+	nSession++
+	idSession++
+
+	return idSession - 1, nil
+}
+
+// ValidateSession checks that the session is still active, has not expired or been idle for
+// longer than batasIdle, and that its user still exists and may access the application.
+// On success the current user data is copied to the provided pointer and the idle timer is reset.
+// On failure the session is ended.
+func ValidateSession(sessionId int, user *User) error {
+	var left, right, mid int
+
+	left = 0
+	right = nSession - 1
+
+	for left <= right {
+		mid = (left + right) / 2
+
+		if sessions[mid].id == sessionId {
+			now := time.Now()
+
+			if now.After(sessions[mid].kedaluwarsa) {
+				EndSession(sessionId)
+				return fmt.Errorf("sesi telah berakhir, silakan login kembali")
+			}
+
+			if sessionExpired(sessions[mid], now) {
+				EndSession(sessionId)
+				return fmt.Errorf("sesi berakhir karena tidak ada aktivitas selama %s, silakan login kembali", batasIdle.String())
+			}
+
+			if err := FindUserById(sessions[mid].userId, user); err != nil {
+				EndSession(sessionId)
+				return fmt.Errorf("akun tidak ditemukan, sesi diakhiri")
+			}
+
+			if err := CheckUserAccess(*user); err != nil {
+				EndSession(sessionId)
+				return err
+			}
+
+			sessions[mid].terakhirAktif = now
+			return nil
+		}
+
+		if sessions[mid].id < sessionId {
+			left = mid + 1
+		} else {
+			right = mid - 1
+		}
+	}
+
+	return fmt.Errorf("sesi tidak ditemukan, silakan login kembali")
+}
+
+// checkSession validates the session behind a view before an action and refreshes user.
+// Session ID 0 stands for the administrator, who has no session; user is then left empty.
+func checkSession(sessionId int, user *User) error {
+	if sessionId == 0 {
+		*user = User{}
+		return nil
+	}
+	return ValidateSession(sessionId, user)
+}
+
+// isSessionActive reports whether the session with the specified ID still exists using binary search.
+// Unlike ValidateSession it does not count as activity.
+func isSessionActive(sessionId int) bool {
+	var left, right, mid int
+
+	left = 0
+	right = nSession - 1
+
+	for left <= right {
+		mid = (left + right) / 2
+
+		if sessions[mid].id == sessionId {
+			return true
+		}
+
+		if sessions[mid].id < sessionId {
+			left = mid + 1
+		} else {
+			right = mid - 1
+		}
+	}
+
+	return false
+}
+
+// EndSession removes the session with the specified ID using binary search.
+// It is used for an explicit logout as well as for sessions that are no longer valid.
+func EndSession(sessionId int) error {
+	var left, right, mid int
+
+	left = 0
+	right = nSession - 1
+
+	for left <= right {
+		mid = (left + right) / 2
+
+		if sessions[mid].id == sessionId {
+			for j := mid; j < nSession-1; j++ {
+				sessions[j] = sessions[j+1]
+			}
+			sessions[nSession-1] = Session{}
+			nSession--
+			return nil
+		}
+
+		if sessions[mid].id < sessionId {
+			left = mid + 1
+		} else {
+			right = mid - 1
+		}
+	}
+
+	return fmt.Errorf("sesi dengan ID %d tidak ditemukan", sessionId)
+}
+
+// GetSessions copies all active sessions to the provided array.
+func GetSessions(sessionsData *[NMAX]Session) error {
+	if nSession == 0 {
+		return fmt.Errorf("tidak ada sesi aktif")
+	}
+
+	for i := 0; i < nSession; i++ {
+		sessionsData[i] = sessions[i]
+	}
+
+	return nil
+}
+
+// SetSessionPolicy changes the maximum session lifetime and the idle timeout.
+func SetSessionPolicy(durasi, idle time.Duration) error {
+	if durasi <= 0 || idle <= 0 {
+		return fmt.Errorf("durasi sesi dan batas idle harus lebih dari 0")
+	}
+
+	if idle > durasi {
+		return fmt.Errorf("batas idle tidak boleh lebih lama dari durasi sesi")
+	}
+
+	durasiSesi = durasi
+	batasIdle = idle
+	return nil
+}
+
+// sessionExpired reports whether the session has passed its lifetime or its idle timeout at the given time.
+func sessionExpired(session Session, now time.Time) bool {
+	return now.After(session.kedaluwarsa) || now.Sub(session.terakhirAktif) > batasIdle
+}
+
+// CheckLoginLock returns an error when the username is locked because of repeated failed logins.
+func CheckLoginLock(username string) error {
+	guard := findLoginGuard(username)
+	if guard != nil && time.Now().Before(guard.terkunciSampai) {
+		return fmt.Errorf("terlalu banyak percobaan gagal, akun terkunci hingga %s", guard.terkunciSampai.Format("02-01-2006 15:04:05"))
+	}
+	return nil
+}
+
+// RecordLoginFailure records a failed login for the username. Once batasGagalLogin consecutive
+// failures are reached the username is locked. The lockout starts at durasiKunciDasar and doubles
+// with every further lockout before a successful login, up to durasiKunciMaks.
+// The attempt is counted on the account behind the username, or on the name itself when it does not exist.
+func RecordLoginFailure(username, keterangan string) {
+	var user User
+
+	if username != adminLoginName && FindUserByUsername(username, &user) == nil {
+		username = user.username
+	}
+
+	guard := findLoginGuard(username)
+	if guard == nil {
+		guard = newLoginGuard(username)
+	}
+
+	guard.gagal++
+	if guard.gagal >= batasGagalLogin {
+		durasi := durasiKunciDasar
+		for k := 0; k < guard.penguncian && durasi < durasiKunciMaks; k++ {
+			durasi *= 2
+		}
+		if durasi > durasiKunciMaks {
+			durasi = durasiKunciMaks
+		}
+
+		guard.gagal = 0
+		guard.penguncian++
+		guard.terkunciSampai = time.Now().Add(durasi)
+		keterangan += fmt.Sprintf(", terkunci selama %s", durasi.String())
+	}
+
+	RecordLoginAttempt(username, false, keterangan)
+}
+
+// RecordLoginSuccess records a successful login and resets the failure counters of the username.
+func RecordLoginSuccess(username string) {
+	guard := findLoginGuard(username)
+	if guard != nil {
+		guard.gagal = 0
+		guard.penguncian = 0
+		guard.terkunciSampai = time.Time{}
+	}
+
+	RecordLoginAttempt(username, true, "login berhasil")
+}
+
+// RecordLoginAttempt stores a login attempt in loginLog, discarding the oldest entry when full.
+func RecordLoginAttempt(username string, berhasil bool, keterangan string) {
+	if nLoginLog >= NMAX {
+		for i := 0; i < NMAX-1; i++ {
+			loginLog[i] = loginLog[i+1]
+		}
+		nLoginLog--
+	}
+
+	loginLog[nLoginLog] = LoginRecord{username: username, berhasil: berhasil, keterangan: keterangan, waktu: time.Now()}
+	nLoginLog++
+}
+
+// UnlockLogin lifts the lockout of a username and resets its failure counters.
+func UnlockLogin(username string) error {
+	guard := findLoginGuard(username)
+	if guard == nil || (guard.gagal == 0 && !time.Now().Before(guard.terkunciSampai)) {
+		return fmt.Errorf("username '%s' tidak sedang terkunci", username)
+	}
+
+	guard.gagal = 0
+	guard.penguncian = 0
+	guard.terkunciSampai = time.Time{}
+	RecordLoginAttempt(username, false, "kunci dibuka oleh admin")
+	return nil
+}
+
+// SetLockoutPolicy changes the number of failed attempts that locks a username and the length of the first lockout.
+func SetLockoutPolicy(batas int, durasi time.Duration) error {
+	if batas < 1 || durasi <= 0 {
+		return fmt.Errorf("jumlah gagal dan durasi penguncian harus lebih dari 0")
+	}
+
+	batasGagalLogin = batas
+	durasiKunciDasar = durasi
+	return nil
+}
+
+// GetLoginLog retrieves the login history and copies it to the provided array.
+func GetLoginLog(logInput *[NMAX]LoginRecord) error {
+	if nLoginLog == 0 {
+		return fmt.Errorf("belum ada riwayat login")
+	}
+
+	*logInput = loginLog
+	return nil
+}
+
+// findLoginGuard returns the guard of the account with the username using sequential search, or the guard
+// of the name itself for the administrator and unknown usernames, ignoring case. It returns nil if absent.
+func findLoginGuard(username string) *LoginGuard {
+	var user User
+
+	if username != adminLoginName && FindUserByUsername(username, &user) == nil {
+		for i := 0; i < nLoginGuard; i++ {
+			if loginGuards[i].userId == user.id {
+				return &loginGuards[i]
+			}
+		}
+		return nil
+	}
+
+	if username == adminLoginName {
+		for i := 0; i < nLoginGuard; i++ {
+			if loginGuards[i].username == adminLoginName {
+				return &loginGuards[i]
+			}
+		}
+		return nil
+	}
+
+	for i := 0; i < nUnknownLoginGuard; i++ {
+		if toLower(unknownLoginGuards[i].username) == toLower(username) {
+			return &unknownLoginGuards[i]
+		}
+	}
+	return nil
+}
+
+// newLoginGuard creates the guard of the account with the username, or of the name itself for the
+// administrator and unknown usernames, and returns it.
+// When loginGuards is full, an entry without failures or lockout, or one of a deleted account, is reused;
+// there is always one because the array has room for every account. When unknownLoginGuards is full,
+// an entry without failures or lockout is reused, or else the one whose lockout ends first.
+func newLoginGuard(username string) *LoginGuard {
+	var user User
+	var guard LoginGuard
+
+	now := time.Now()
+
+	if username != adminLoginName && FindUserByUsername(username, &user) != nil {
+		i := nUnknownLoginGuard
+		if nUnknownLoginGuard < NMAX {
+			nUnknownLoginGuard++
+		} else {
+			i = 0
+			for j := 0; j < nUnknownLoginGuard; j++ {
+				if unknownLoginGuards[j].gagal == 0 && !now.Before(unknownLoginGuards[j].terkunciSampai) {
+					i = j
+					break
+				}
+				if unknownLoginGuards[j].terkunciSampai.Before(unknownLoginGuards[i].terkunciSampai) {
+					i = j
+				}
+			}
+		}
+
+		unknownLoginGuards[i] = LoginGuard{username: username}
+		return &unknownLoginGuards[i]
+	}
+
+	if username == adminLoginName {
+		guard = LoginGuard{username: adminLoginName}
+	} else {
+		guard = LoginGuard{userId: user.id}
+	}
+
+	i := nLoginGuard
+	if nLoginGuard < len(loginGuards) {
+		nLoginGuard++
+	} else {
+		i = 0
+		for j := 0; j < nLoginGuard; j++ {
+			idle := loginGuards[j].gagal == 0 && !now.Before(loginGuards[j].terkunciSampai)
+			deleted := loginGuards[j].userId != 0 && FindUserById(loginGuards[j].userId, &user) != nil
+			if idle || deleted {
+				i = j
+				break
+			}
+		}
+	}
+
+	loginGuards[i] = guard
+	return &loginGuards[i]
+}
+
+// loginGuardName returns the name shown for a guard: the current username of its account,
+// or the name it was created for.
+func loginGuardName(guard LoginGuard) string {
+	var user User
+
+	if guard.userId != 0 && FindUserById(guard.userId, &user) == nil {
+		return user.username
+	}
+	return guard.username
+}
+
+// CheckUserAccess returns an error describing the sanction when the user account is suspended or banned.
+// A suspension whose end date has passed no longer blocks the user.
+func CheckUserAccess(user User) error {
+	switch userState(user) {
+	case "banned":
+		return fmt.Errorf("akun Anda diblokir permanen (alasan: %s)", user.alasanSanksi)
+	case "suspended":
+		return fmt.Errorf("akun Anda ditangguhkan hingga %s (alasan: %s)", user.ditangguhkan.Format("02-01-2006 15:04"), user.alasanSanksi)
+	}
+	return nil
+}
+
+// SuspendUser suspends a user account until the given time and records the sanction.
+func SuspendUser(userId int, sampai time.Time, alasan string) error {
+	if !sampai.After(time.Now()) {
+		return fmt.Errorf("lama penangguhan harus lebih dari 0 hari")
+	}
+	return applySanction(userId, "suspend", alasan, sampai)
+}
+
+// BanUser permanently bans a user account and records the sanction.
+func BanUser(userId int, alasan string) error {
+	return applySanction(userId, "ban", alasan, time.Time{})
+}
+
+// LiftSanction restores a suspended or banned user account to active and records the action.
+func LiftSanction(userId int, alasan string) error {
+	var user User
+
+	if err := FindUserById(userId, &user); err != nil {
+		return err
+	}
+
+	if userState(user) == "active" {
+		return fmt.Errorf("pengguna dengan ID %d tidak sedang dikenai sanksi", userId)
+	}
+
+	return applySanction(userId, "lift", alasan, time.Time{})
+}
+
+// applySanction updates the account state of a user using binary search to find the user,
+// then appends the action to the sanction history, discarding the oldest entry when the history is full.
+// It assumes that the users array is sorted by ID in ascending order.
+func applySanction(userId int, jenis, alasan string, sampai time.Time) error {
+	var left, right, mid int
+
+	if alasan == "" {
+		return fmt.Errorf("alasan sanksi tidak boleh kosong")
+	}
+
+	left = 0
+	right = nUser - 1
+
+	for left <= right {
+		mid = (left + right) / 2
+
+		if users[mid].id == userId {
+			switch jenis {
+			case "suspend":
+				users[mid].status = "suspended"
+				users[mid].ditangguhkan = sampai
+				users[mid].alasanSanksi = alasan
+			case "ban":
+				users[mid].status = "banned"
+				users[mid].ditangguhkan = time.Time{}
+				users[mid].alasanSanksi = alasan
+			case "lift":
+				users[mid].status = "active"
+				users[mid].ditangguhkan = time.Time{}
+				users[mid].alasanSanksi = ""
+			}
+
+			if nSanction >= NMAX {
+				for i := 0; i < NMAX-1; i++ {
+					sanctions[i] = sanctions[i+1]
+				}
+				nSanction--
+			}
+
+			sanctions[nSanction] = Sanction{
+				id:     idSanction,
+				userId: userId,
+				jenis:  jenis,
+				alasan: alasan,
+				sampai: sampai,
+				dibuat: time.Now(),
+			}
+			nSanction++
+			idSanction++
+			return nil
+		}
+
+		if users[mid].id < userId {
+			left = mid + 1
+		} else {
+			right = mid - 1
+		}
+	}
+
+	return fmt.Errorf("pengguna dengan ID %d tidak ditemukan", userId)
+}
+
+// GetSanctions retrieves the sanction history and copies it to the provided array.
+func GetSanctions(sanctionsInput *[NMAX]Sanction) error {
+	if nSanction == 0 {
+		return fmt.Errorf("belum ada sanksi yang tercatat")
+	}
+
+	*sanctionsInput = sanctions
+	return nil
+}
+
+// CreateComment adds a new comment to the system with the specified content.
+// It assigns a unique ID to the comment, associates it with the given user,
+// and records the category and confidence predicted by the sentiment analyzer.
+// duplikatDari is the ID of the comment the new one duplicates, or 0 if it is not a duplicate.
+func CreateComment(user User, komentar string, duplikatDari int) error {
+	var result SentimentResult
+
+	if nComment >= NMAX {
+		return fmt.Errorf("jumlah komentar sudah mencapai batas maksimum")
+	}
+
+	if user.id != 0 {
+		var current User
+		if err := FindUserById(user.id, &current); err != nil {
+			return err
+		} else if err := CheckUserAccess(current); err != nil {
+			return err
+		}
+	}
+
+	if err := CheckRateLimit(user.id); err != nil {
+		return err
+	}
+
+	AnalyzeSentiment(komentar, &result)
+
+	comments[nComment] = Comment{
+		id:           idComment,
+		userId:       user.id,
+		komentar:     komentar,
+		kategori:     result.kategori,
+		keyakinan:    result.keyakinan,
+		dibuat:       time.Now(),
+		duplikatDari: duplikatDari,
+	}
+	AnalyzeAspects(result, &comments[nComment])
+	comments[nComment].skorToksisitas = AnalyzeToxicity(komentar)
+	comments[nComment].toksik = comments[nComment].skorToksisitas >= ambangToksisitas
+	updateShingles(&comments[nComment])
+	setInitialStatus(&comments[nComment])
+	IndexComment(comments[nComment])
+	nComment++
+	idComment++
+	RecordPostAttempt(user.id)
+	return nil
+}
+
+// setInitialStatus assigns the moderation status of a new comment. Comments flagged as toxic
+// or duplicate start as pending so a moderator can review them; all others are approved.
+func setInitialStatus(comment *Comment) {
+	comment.status = "approved"
+	comment.alasanStatus = "otomatis"
+	if comment.toksik {
+		comment.status = "pending"
+		comment.alasanStatus = "otomatis: terdeteksi toksik"
+	} else if comment.duplikatDari != 0 {
+		comment.status = "pending"
+		comment.alasanStatus = "otomatis: duplikat"
+	}
+	comment.statusOleh = "sistem"
+	comment.statusDiubah = time.Now()
+}
+
+// ChangeCommentStatus moves a comment to a new moderation status using binary search to find it.
+// The change must be allowed by transisiStatus, and a reason is required when hiding or removing
+// a comment. The reason, the actor, and the time of the change are recorded on the comment.
+// It assumes that the comments array is sorted by ID in ascending order.
+func ChangeCommentStatus(id int, status, alasan, aktor string) error {
+	var left, right, mid int
+
+	to := statusIndex(status)
+	if to == -1 {
+		return fmt.Errorf("status harus 'pending', 'approved', 'hidden', atau 'removed'")
+	}
+
+	if (status == "hidden" || status == "removed") && alasan == "" {
+		return fmt.Errorf("alasan wajib diisi untuk status '%s'", status)
+	}
+
+	left = 0
+	right = nComment - 1
+
+	for left <= right {
+		mid = (left + right) / 2
+
+		if comments[mid].id == id {
+			from := statusIndex(comments[mid].status)
+			if from == -1 || !transisiStatus[from][to] {
+				return fmt.Errorf("status tidak dapat diubah dari '%s' ke '%s'", comments[mid].status, status)
+			}
+
+			comments[mid].status = status
+			comments[mid].alasanStatus = alasan
+			comments[mid].statusOleh = aktor
+			comments[mid].statusDiubah = time.Now()
+			return nil
+		}
+
+		if comments[mid].id < id {
+			left = mid + 1
+		} else {
+			right = mid - 1
+		}
+	}
+
+	return fmt.Errorf("komentar dengan ID %d tidak ditemukan", id)
+}
+
+// CountCommentsByStatus counts the number of comments from sumber with the specified moderation status.
+func CountCommentsByStatus(status string, sumber CommentSource) int {
+	var count int
+
+	for i := 0; i < nComment; i++ {
+		if comments[i].status == status && matchSource(comments[i], sumber) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// MergeDuplicateComment merges a new duplicate comment into an existing one instead of storing it.
+// It increments the merge counter of the existing comment using binary search to find it,
+// and counts the post towards the rate limit of the user.
+// It assumes that the comments array is sorted by ID in ascending order.
+func MergeDuplicateComment(user User, id int) error {
+	var left, right, mid int
+
+	if err := CheckRateLimit(user.id); err != nil {
+		return err
+	}
+
+	left = 0
+	right = nComment - 1
+
+	for left <= right {
+		mid = (left + right) / 2
+
+		if comments[mid].id == id {
+			comments[mid].jumlahGabungan++
+			RecordPostAttempt(user.id)
+			return nil
+		}
+
+		if comments[mid].id < id {
+			left = mid + 1
+		} else {
+			right = mid - 1
+		}
+	}
+
+	return fmt.Errorf("komentar dengan ID %d tidak ditemukan", id)
+}
+
+// CheckRateLimit returns an error when the user has already posted batasKomentar comments
+// within the last jendelaBatas. Comments created by administrators (user ID 0) are not limited.
+func CheckRateLimit(userId int) error {
+	var count int
+
+	if userId == 0 {
+		return nil
+	}
+
+	batas := time.Now().Add(-jendelaBatas)
+	for i := 0; i < nRiwayatPost; i++ {
+		if riwayatPost[i].userId == userId && riwayatPost[i].waktu.After(batas) {
+			count++
+		}
+	}
+
+	if count >= batasKomentar {
+		return fmt.Errorf("batas %d komentar per %s tercapai, silakan coba lagi nanti", batasKomentar, jendelaBatas.String())
+	}
+
+	return nil
+}
+
+// RecordPostAttempt stores a post of the user in riwayatPost, discarding the oldest entry when full.
+func RecordPostAttempt(userId int) {
+	if nRiwayatPost >= NMAX {
+		for i := 0; i < NMAX-1; i++ {
+			riwayatPost[i] = riwayatPost[i+1]
+		}
+		nRiwayatPost--
+	}
+
+	riwayatPost[nRiwayatPost] = PostAttempt{userId: userId, waktu: time.Now()}
+	nRiwayatPost++
+}
+
+// SetRateLimit changes the maximum number of comments a user may post within the given window.
+func SetRateLimit(batas int, jendela time.Duration) error {
+	if batas < 1 || jendela <= 0 {
+		return fmt.Errorf("batas komentar dan jendela waktu harus lebih dari 0")
+	}
+
+	batasKomentar = batas
+	jendelaBatas = jendela
+	return nil
+}
+
+// SetSimilarityThreshold changes the minimum similarity for two comments to be considered duplicates.
+func SetSimilarityThreshold(ambang float64) error {
+	if ambang <= 0 || ambang > 1 {
+		return fmt.Errorf("ambang kemiripan harus lebih dari 0 dan paling besar 1")
+	}
+
+	ambangKemiripan = ambang
+	clusterValid = false
+	return nil
+}
+
+// FindSimilarComment searches all comments for the one most similar to the given text.
+// It reports whether a comment with a similarity of at least ambangKemiripan was found, and if so
+// copies it to the provided comment pointer and stores the similarity.
+func FindSimilarComment(komentar string, comment *Comment, kemiripan *float64) bool {
+	var shingles [NSHINGLE]string
+	var nShingle int
+	var found bool
+
+	buildShingles(normalizeText(komentar), &shingles, &nShingle)
+
+	*kemiripan = 0
+	for i := 0; i < nComment; i++ {
+		sim := jaccardSimilarity(shingles[:nShingle], comments[i].shingles)
+
+		if sim >= ambangKemiripan && sim > *kemiripan {
+			*comment = comments[i]
+			*kemiripan = sim
+			found = true
+		}
+	}
+
+	return found
+}
+
+// GetDuplicateClusters groups comments whose similarity is at least ambangKemiripan.
+// Two comments belong to the same cluster when they are linked by a chain of similar pairs.
+// The cluster number of each comment is stored in cluster, in the same order as the comments
+// array, and nCluster receives the number of clusters with at least two comments. Comments
+// that are not part of such a cluster get the cluster number 0.
+// The result is cached and only computed again after the comments or ambangKemiripan change.
+func GetDuplicateClusters(cluster *[NMAX]int, nCluster *int) {
+	var parent [NMAX]int
+	var size [NMAX]int
+	var number [NMAX]int
+
+	if clusterValid {
+		*cluster = duplicateClusters
+		*nCluster = nDuplicateCluster
+		return
+	}
+
+	for i := 0; i < nComment; i++ {
+		parent[i] = i
+	}
+
+	for i := 0; i < nComment; i++ {
+		for j := i + 1; j < nComment; j++ {
+			if jaccardSimilarity(comments[i].shingles, comments[j].shingles) >= ambangKemiripan {
+				rootI := findRoot(&parent, i)
+				rootJ := findRoot(&parent, j)
+				if rootI != rootJ {
+					parent[rootJ] = rootI
+				}
+			}
+		}
+	}
+
+	for i := 0; i < nComment; i++ {
+		size[findRoot(&parent, i)]++
+	}
+
+	*nCluster = 0
+	for i := 0; i < nComment; i++ {
+		root := findRoot(&parent, i)
+		if size[root] < 2 {
+			cluster[i] = 0
+			continue
+		}
+
+		if number[root] == 0 {
+			*nCluster++
+			number[root] = *nCluster
+		}
+		cluster[i] = number[root]
+	}
+
+	duplicateClusters = *cluster
+	nDuplicateCluster = *nCluster
+	clusterValid = true
+}
+
+// findRoot returns the representative of the cluster containing i, compressing the path along the way.
+func findRoot(parent *[NMAX]int, i int) int {
+	for parent[i] != i {
+		parent[i] = parent[parent[i]]
+		i = parent[i]
+	}
+	return i
+}
+
+// MatchCommentFilter reports whether a comment meets every non-empty condition of the filter.
+func MatchCommentFilter(comment Comment, filter CommentFilter) bool {
+	if filter.status != "" && comment.status != filter.status {
+		return false
+	}
+
+	if filter.kategori != "" && effectiveKategori(comment) != filter.kategori {
+		return false
+	}
+
+	if filter.penulisId != 0 && comment.userId != filter.penulisId {
+		return false
+	}
+
+	if !filter.dari.IsZero() && comment.dibuat.Before(filter.dari) {
+		return false
+	}
+	if !filter.sampai.IsZero() && !comment.dibuat.Before(filter.sampai.AddDate(0, 0, 1)) {
+		return false
+	}
+
+	panjang := len([]rune(comment.komentar))
+	if panjang < filter.panjangMin || (filter.panjangMax > 0 && panjang > filter.panjangMax) {
+		return false
+	}
+
+	for i := 0; i < 4; i++ {
+		if filter.tanda[i] && !hasModerationFlag(comment, i) {
+			return false
+		}
+	}
+
+	return matchSource(comment, filter.sumber)
+}
+
+// hasModerationFlag reports whether a comment carries the moderation flag at index i of tandaList.
+func hasModerationFlag(comment Comment, i int) bool {
+	switch i {
+	case 0:
+		return comment.toksik
+	case 1:
+		return comment.duplikatDari != 0
+	case 2:
+		return comment.jumlahGabungan > 0
+	case 3:
+		return comment.kategoriKonfirmasi == "" && comment.keyakinan < ambangKeyakinan
+	}
+	return false
+}
+
+// ValidateCommentFilter checks that the ranges of a filter are not negative or reversed.
+func ValidateCommentFilter(filter CommentFilter) error {
+	if filter.panjangMin < 0 || filter.panjangMax < 0 {
+		return fmt.Errorf("panjang tidak boleh negatif")
+	}
+	if filter.panjangMax > 0 && filter.panjangMin > filter.panjangMax {
+		return fmt.Errorf("panjang minimum tidak boleh lebih dari panjang maksimum")
+	}
+	if !filter.dari.IsZero() && !filter.sampai.IsZero() && filter.sampai.Before(filter.dari) {
+		return fmt.Errorf("tanggal akhir tidak boleh sebelum tanggal awal")
+	}
+	return nil
+}
+
+// SaveCommentFilter stores a filter of ownerId under a name. A filter the same owner saved earlier
+// under the same name, ignoring case, is replaced.
+func SaveCommentFilter(ownerId int, nama string, filter CommentFilter) error {
+	var nMilik int
+
+	if nama == "" {
+		return fmt.Errorf("nama filter tidak boleh kosong")
+	}
+
+	if err := ValidateCommentFilter(filter); err != nil {
+		return err
+	}
+
+	for i := 0; i < nSavedFilter; i++ {
+		if savedFilters[i].ownerId != ownerId {
+			continue
+		}
+		if toLower(savedFilters[i].nama) == toLower(nama) {
+			savedFilters[i].filter = filter
+			return nil
+		}
+		nMilik++
+	}
+
+	if nMilik >= NFILTER {
+		return fmt.Errorf("filter tersimpan sudah penuh, maksimal %d filter", NFILTER)
+	}
+	if nSavedFilter >= NMAX {
+		return fmt.Errorf("penyimpanan filter sudah penuh")
+	}
+
+	savedFilters[nSavedFilter] = SavedFilter{ownerId: ownerId, nama: nama, filter: filter}
+	nSavedFilter++
+	return nil
+}
+
+// GetSavedFilters copies the filters saved by ownerId to the provided array and stores their count in n.
+func GetSavedFilters(ownerId int, filtersInput *[NFILTER]SavedFilter, n *int) error {
+	*n = 0
+	for i := 0; i < nSavedFilter; i++ {
+		if savedFilters[i].ownerId == ownerId {
+			filtersInput[*n] = savedFilters[i]
+			*n++
+		}
+	}
+
+	if *n == 0 {
+		return fmt.Errorf("belum ada filter yang disimpan")
+	}
+	return nil
+}
+
+// DeleteSavedFilters removes every filter saved by ownerId.
+func DeleteSavedFilters(ownerId int) {
+	var j int
+
+	for i := 0; i < nSavedFilter; i++ {
+		if savedFilters[i].ownerId != ownerId {
+			savedFilters[j] = savedFilters[i]
+			j++
+		}
+	}
+	for i := j; i < nSavedFilter; i++ {
+		savedFilters[i] = SavedFilter{}
+	}
+	nSavedFilter = j
+}
+
+// GoToPage moves a listing with total entries to the given page.
+func GoToPage(page *Pagination, halaman, total int) error {
+	nHalaman := pageCount(total, page.ukuran)
+
+	if halaman < 1 || halaman > nHalaman {
+		return fmt.Errorf("halaman %d tidak ada, pilih halaman 1 sampai %d", halaman, nHalaman)
+	}
+
+	page.halaman = halaman
+	return nil
+}
+
+// NextPage moves a listing with total entries to the page after the current one.
+func NextPage(page *Pagination, total int) error {
+	if page.halaman >= pageCount(total, page.ukuran) {
+		return fmt.Errorf("sudah di halaman terakhir")
+	}
+	return GoToPage(page, page.halaman+1, total)
+}
+
+// PrevPage moves a listing with total entries to the page before the current one.
+func PrevPage(page *Pagination, total int) error {
+	if page.halaman <= 1 {
+		return fmt.Errorf("sudah di halaman pertama")
+	}
+	return GoToPage(page, page.halaman-1, total)
+}
+
+// SetPageSize changes the number of entries per page and goes back to the first page.
+func SetPageSize(page *Pagination, ukuran int) error {
+	if ukuran < 1 || ukuran > NMAX {
+		return fmt.Errorf("jumlah per halaman harus antara 1 dan %d", NMAX)
+	}
+
+	page.ukuran = ukuran
+	page.halaman = 1
+	return nil
+}
+
+// ClampPage keeps the current page within the pages of a listing with total entries,
+// for example after entries were deleted from the last page.
+func ClampPage(page *Pagination, total int) {
+	nHalaman := pageCount(total, page.ukuran)
+
+	if page.halaman > nHalaman {
+		page.halaman = nHalaman
+	}
+	if page.halaman < 1 {
+		page.halaman = 1
+	}
+}
+
+// matchSource reports whether every non-empty field of sumber matches the source of the comment.
+func matchSource(comment Comment, sumber CommentSource) bool {
+	return matchSourceField(comment.sumber.platform, sumber.platform) &&
+		matchSourceField(comment.sumber.postId, sumber.postId) &&
+		matchSourceField(comment.sumber.idEksternal, sumber.idEksternal) &&
+		matchSourceField(comment.sumber.handle, sumber.handle)
+}
+
+// matchSourceField reports whether a source field equals the filter value, ignoring case.
+// An empty filter value matches every field.
+func matchSourceField(value, filter string) bool {
+	return filter == "" || toLower(value) == toLower(filter)
+}
+
+// SetCommentSource sets the source of the comment with the specified ID using binary search.
+// It assumes that the comments array is sorted by ID in ascending order.
+func SetCommentSource(id int, sumber CommentSource) error {
+	var left, right, mid int
+
+	left = 0
+	right = nComment - 1
+
+	for left <= right {
+		mid = (left + right) / 2
+
+		if comments[mid].id == id {
+			comments[mid].sumber = sumber
+			return nil
+		}
+
+		if comments[mid].id < id {
+			left = mid + 1
+		} else {
+			right = mid - 1
+		}
+	}
+
+	return fmt.Errorf("komentar dengan ID %d tidak ditemukan", id)
+}
+
+// ImportComments reads comments from a text file, one comment per line, in the format
+// platform|post_id|id_eksternal|handle|komentar. Empty lines and lines starting with '#' are ignored.
+// Lines with missing fields and comments whose platform and external ID were already imported
+// are skipped. Near-duplicate texts are imported but flagged, so they wait for moderation.
+func ImportComments(path string, diimpor, dilewati *int) error {
+	var fields [5]string
+	var original Comment
+	var kemiripan float64
+
+	*diimpor = 0
+	*dilewati = 0
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("gagal membaca file: %s", err.Error())
+	}
+
+	start := 0
+	for start < len(data) {
+		end := start
+		for end < len(data) && data[end] != '\n' {
+			end++
+		}
+		line := string(data[start:end])
+		start = end + 1
+
+		for len(line) > 0 && (line[len(line)-1] == '\r' || line[len(line)-1] == ' ') {
+			line = line[:len(line)-1]
+		}
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		nField := 0
+		fieldStart := 0
+		for i := 0; i < len(line) && nField < 4; i++ {
+			if line[i] == '|' {
+				fields[nField] = line[fieldStart:i]
+				nField++
+				fieldStart = i + 1
+			}
+		}
+		fields[nField] = line[fieldStart:]
+
+		if nField < 4 || fields[0] == "" || fields[4] == "" {
+			*dilewati++
+			continue
+		}
+
+		sumber := CommentSource{platform: fields[0], postId: fields[1], idEksternal: fields[2], handle: fields[3]}
+		if sumber.idEksternal != "" && isCommentImported(sumber) {
+			*dilewati++
+			continue
+		}
+
+		if nComment >= NMAX {
+			return fmt.Errorf("jumlah komentar sudah mencapai batas maksimum, %d komentar diimpor", *diimpor)
+		}
+
+		duplikatDari := 0
+		if FindSimilarComment(fields[4], &original, &kemiripan) {
+			duplikatDari = original.id
+		}
+
+		if err := CreateComment(User{}, fields[4], duplikatDari); err != nil {
+			*dilewati++
+			continue
+		}
+		comments[nComment-1].sumber = sumber
+		*diimpor++
+	}
+
+	return nil
+}
+
+// isCommentImported reports whether a comment with the same platform and external ID already exists.
+func isCommentImported(sumber CommentSource) bool {
+	for i := 0; i < nComment; i++ {
+		if matchSourceField(comments[i].sumber.platform, sumber.platform) && comments[i].sumber.idEksternal == sumber.idEksternal {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSourceStats groups the comments matching sumber by platform and post and counts the categories and
+// toxic comments of each group. Comments without a source are grouped under an empty platform.
+func GetSourceStats(sumber CommentSource, stats *[NMAX]SourceStats, n *int) {
+	*n = 0
+
+	for i := 0; i < nComment; i++ {
+		if !matchSource(comments[i], sumber) {
+			continue
+		}
+
+		g := -1
+		for j := 0; j < *n && g == -1; j++ {
+			if toLower(stats[j].platform) == toLower(comments[i].sumber.platform) && stats[j].postId == comments[i].sumber.postId {
+				g = j
+			}
+		}
+
+		if g == -1 {
+			g = *n
+			stats[g] = SourceStats{platform: comments[i].sumber.platform, postId: comments[i].sumber.postId}
+			*n++
+		}
+
+		k := kategoriIndex(effectiveKategori(comments[i]))
+		if k != -1 {
+			stats[g].perKategori[k]++
+		}
+		if comments[i].toksik {
+			stats[g].toksik++
+		}
+	}
+}
+
+// countCommentsByUser counts the comments written by the user with the specified ID.
+func countCommentsByUser(userId int) int {
+	var n int
+
+	for i := 0; i < nComment; i++ {
+		if comments[i].userId == userId {
+			n++
+		}
+	}
+
+	return n
+}
+
+// CountCommentsBySource counts the comments whose source matches sumber; an empty source counts every comment.
+func CountCommentsBySource(sumber CommentSource) int {
+	var count int
+
+	for i := 0; i < nComment; i++ {
+		if matchSource(comments[i], sumber) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// CountCommentsByCategory counts the number of comments from sumber that match the specified category.
+// It iterates through all comments in the global comments array and increments a counter
+// each time it finds a comment whose confirmed category, or predicted category when it has
+// not been confirmed, matches.
+func CountCommentsByCategory(category string, sumber CommentSource) int {
+	var count int
+
+	for i := 0; i < nComment; i++ {
+		if effectiveKategori(comments[i]) == category && matchSource(comments[i], sumber) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// GetComments retrieves all available comments from the system and copies them to the provided array.
+func GetComments(commentsInput *[NMAX]Comment) error {
+	if nComment == 0 {
+		return fmt.Errorf("tidak ada komentar yang tersedia")
+	}
+
+	*commentsInput = comments
+
+	return nil
+}
+
+// GetCommentsSearch searches through all comments for those matching the search query.
+// The query is parsed with ParseQuery, so it may combine words, quoted phrases, wildcards,
+// and field filters with AND, OR, and NOT. A single word keeps the old behaviour of a
+// case-insensitive substring search. The comparison is done per rune so that emoji
+// and other multi-byte characters are matched whole, and emoji modifiers are ignored.
+func GetCommentsSearch(commentsInput *[NMAX]Comment, search string) error {
+	var matchCount int
+	var query Query
+
+	if nComment == 0 {
+		return fmt.Errorf("tidak ada komentar yang tersedia")
+	}
+
+	if err := ParseQuery(search, &query); err != nil {
+		return err
+	}
+
+	var tempComments [NMAX]Comment
+	matchCount = 0
+
+	for i := 0; i < nComment; i++ {
+		if EvaluateQuery(&query, comments[i]) {
+			tempComments[matchCount] = comments[i]
+			matchCount++
+		}
+	}
+
+	if matchCount == 0 {
+		return fmt.Errorf("tidak ada komentar yang sesuai dengan pencarian")
+	}
+
+	for i := 0; i < NMAX; i++ {
+		if i < matchCount {
+			commentsInput[i] = tempComments[i]
+		} else {
+			commentsInput[i] = Comment{}
+		}
+	}
+
+	return nil
+}
+
+// NewSearchMatcher prepares a search in the given mode: "kata" for a plain substring, "query" for the
+// query language of ParseQuery, "fuzzy" for typo-tolerant words, "fuzzyNama" for a typo-tolerant
+// username, "relevansi" for BM25 ranking, and "regex" for a regular expression.
+func NewSearchMatcher(mode, teks string, m *SearchMatcher) error {
+	*m = SearchMatcher{mode: mode, teks: teks}
+
+	switch mode {
+	case "kata", "fuzzyNama":
+		if teks == "" {
+			return fmt.Errorf("kata kunci tidak boleh kosong")
+		}
+	case "query":
+		return ParseQuery(teks, &m.query)
+	case "regex":
+		return CompileRegex(teks, &m.regex)
+	case "fuzzy", "relevansi":
+		tokenize(teks, &m.kata, &m.nKata)
+		if m.nKata == 0 {
+			return fmt.Errorf("kata kunci tidak boleh kosong")
+		}
+	default:
+		return fmt.Errorf("mode pencarian '%s' tidak dikenal", mode)
+	}
+
+	return nil
+}
+
+// SearchComments runs a prepared search over the comments and copies the results to the provided array.
+func SearchComments(m *SearchMatcher, commentsInput *[NMAX]Comment) error {
+	switch m.mode {
+	case "query":
+		return GetCommentsSearch(commentsInput, m.teks)
+	case "fuzzy":
+		return GetCommentsFuzzy(commentsInput, m.teks)
+	case "relevansi":
+		return GetCommentsRanked(commentsInput, m.teks)
+	case "regex":
+		return GetCommentsRegex(commentsInput, &m.regex)
+	}
+	return fmt.Errorf("mode pencarian '%s' tidak dapat dipakai untuk komentar", m.mode)
+}
+
+// SearchUsers runs a prepared search over the usernames and copies the results to the provided array.
+func SearchUsers(m *SearchMatcher, usersInput *[NMAX]User) error {
+	switch m.mode {
+	case "kata":
+		return GetUsersSearch(usersInput, m.teks)
+	case "fuzzyNama":
+		return GetUsersFuzzy(usersInput, m.teks)
+	case "regex":
+		return GetUsersRegex(usersInput, &m.regex)
+	}
+	return fmt.Errorf("mode pencarian '%s' tidak dapat dipakai untuk user", m.mode)
+}
+
+// FindMatchSpans finds the parts of a text matched by a prepared search and stores them as
+// [start, end) rune positions in the original text, sorted and without overlaps.
+// Words and phrases are located in the lowercased text with emoji modifiers removed, using the same
+// comparisons as the search itself, and mapped back to the original positions. Words under NOT
+// and field filters in a query have nothing to highlight.
+func FindMatchSpans(m *SearchMatcher, teks string, spans *[NTOKEN][2]int, n *int) {
+	var norm []rune
+	var asal []int
+
+	*n = 0
+
+	if m.mode == "regex" {
+		var mulai, akhir int
+		var sisa int = langkahRegexMaks
+
+		runes := []rune(teks)
+		if len(runes) > teksRegexMaks {
+			runes = runes[:teksRegexMaks]
+		}
+
+		pos := 0
+		for pos < len(runes) && *n < NTOKEN && FindRegexMatch(&m.regex, runes, pos, &mulai, &akhir, &sisa) {
+			spans[*n] = [2]int{mulai, akhir}
+			*n++
+			pos = akhir
+		}
+		return
+	}
+
+	normalizeWithMap(teks, &norm, &asal)
+
+	switch m.mode {
+	case "kata":
+		addOccurrenceSpans(norm, []rune(normalizeEmoji(toLower(m.teks))), spans, n)
+	case "fuzzyNama":
+		if DamerauLevenshtein(string(norm), toLower(m.teks)) <= jarakFuzzyMaks {
+			addSpan(spans, n, 0, len(norm))
+		}
+	case "query":
+		addQuerySpans(&m.query, m.query.akar, norm, spans, n)
+	case "fuzzy", "relevansi":
+		var words [NTOKEN][2]int
+		var nWord int
+
+		findWordSpans(norm, &words, &nWord)
+		for w := 0; w < nWord; w++ {
+			word := string(norm[words[w][0]:words[w][1]])
+
+			for k := 0; k < m.nKata; k++ {
+				if (m.mode == "fuzzy" && DamerauLevenshtein(word, m.kata[k]) <= jarakFuzzyMaks) || (m.mode == "relevansi" && word == m.kata[k]) {
+					addSpan(spans, n, words[w][0], words[w][1])
+					break
+				}
+			}
+		}
+	}
+
+	for i := 0; i < *n; i++ {
+		spans[i][0] = asal[spans[i][0]]
+		spans[i][1] = asal[spans[i][1]-1] + 1
+	}
+
+	sortAndMergeSpans(spans, n)
+}
+
+// addQuerySpans adds the spans matched by the words and phrases of a query node and its operands.
+// Operands of NOT are skipped, since the comment matched because they do not occur.
+func addQuerySpans(query *Query, i int, norm []rune, spans *[NTOKEN][2]int, n *int) {
+	node := query.nodes[i]
+
+	switch node.jenis {
+	case "and", "or":
+		addQuerySpans(query, node.kiri, norm, spans, n)
+		addQuerySpans(query, node.kanan, norm, spans, n)
+	case "frasa":
+		addOccurrenceSpans(norm, []rune(node.nilai), spans, n)
+	case "kata":
+		if !hasWildcard(node.nilai) {
+			addOccurrenceSpans(norm, []rune(node.nilai), spans, n)
+			return
+		}
+
+		var words [NTOKEN][2]int
+		var nWord int
+
+		findWordSpans(norm, &words, &nWord)
+		for w := 0; w < nWord; w++ {
+			if matchWildcard(norm[words[w][0]:words[w][1]], []rune(node.nilai)) {
+				addSpan(spans, n, words[w][0], words[w][1])
+			}
+		}
+	}
+}
+
+// FormatSearchResult marks the spans in a text for display. Texts longer than panjangSnippetMaks
+// are shortened to the matches with konteksSnippet characters on each side, with "..." where text
+// was left out. A long text without matches is cut after panjangSnippetMaks characters.
+func FormatSearchResult(teks string, spans *[NTOKEN][2]int, n int) string {
+	var hasil string
+
+	runes := []rune(teks)
+	if len(runes) <= panjangSnippetMaks {
+		return highlightRange(runes, 0, len(runes), spans, n)
+	}
+
+	if n == 0 {
+		return highlightRange(runes, 0, panjangSnippetMaks, spans, n) + "..."
+	}
+
+	awal := -1
+	akhir := -1
+	for i := 0; i <= n; i++ {
+		if i < n {
+			mulai := spans[i][0] - konteksSnippet
+			if mulai < 0 {
+				mulai = 0
+			}
+			selesai := spans[i][1] + konteksSnippet
+			if selesai > len(runes) {
+				selesai = len(runes)
+			}
+
+			if awal == -1 {
+				awal, akhir = mulai, selesai
+				continue
+			}
+			if mulai <= akhir {
+				if selesai > akhir {
+					akhir = selesai
+				}
+				continue
+			}
+		}
+
+		if awal > 0 {
+			hasil += "..."
+		}
+		hasil += highlightRange(runes, awal, akhir, spans, n)
+
+		if i < n {
+			awal = spans[i][0] - konteksSnippet
+			if awal < 0 {
+				awal = 0
+			}
+			akhir = spans[i][1] + konteksSnippet
+			if akhir > len(runes) {
+				akhir = len(runes)
+			}
+		}
+	}
+
+	if akhir < len(runes) {
+		hasil += "..."
+	}
+
+	return hasil
+}
+
+// GetCommentsFuzzy searches for comments that contain every word of the search, allowing each
+// word to differ by up to jarakFuzzyMaks edits from a word in the comment. The results are ranked by
+// the sum of the smallest distances of the search words, closest first; comments with the same
+// distance keep their order by ID.
+func GetCommentsFuzzy(commentsInput *[NMAX]Comment, search string) error {
+	var searchTokens, commentTokens [NTOKEN]string
+	var nSearch, nKomentar int
+	var tempComments [NMAX]Comment
+	var jarak [NMAX]int
+	var matchCount int
+
+	if nComment == 0 {
+		return fmt.Errorf("tidak ada komentar yang tersedia")
+	}
+
+	tokenize(search, &searchTokens, &nSearch)
+	if nSearch == 0 {
+		return fmt.Errorf("kata kunci tidak boleh kosong")
+	}
+
+	for i := 0; i < nComment; i++ {
+		tokenize(comments[i].komentar, &commentTokens, &nKomentar)
+
+		total := 0
+		isMatch := true
+		for s := 0; s < nSearch && isMatch; s++ {
+			terdekat := -1
+			for k := 0; k < nKomentar; k++ {
+				d := DamerauLevenshtein(commentTokens[k], searchTokens[s])
+				if terdekat == -1 || d < terdekat {
+					terdekat = d
+				}
+			}
+
+			if terdekat == -1 || terdekat > jarakFuzzyMaks {
+				isMatch = false
+			}
+			total += terdekat
+		}
+
+		if !isMatch {
+			continue
+		}
+
+		j := matchCount - 1
+		for j >= 0 && jarak[j] > total {
+			tempComments[j+1] = tempComments[j]
+			jarak[j+1] = jarak[j]
+			j--
+		}
+		tempComments[j+1] = comments[i]
+		jarak[j+1] = total
+		matchCount++
+	}
+
+	if matchCount == 0 {
+		return fmt.Errorf("tidak ada komentar yang mirip dengan pencarian")
+	}
+
+	for i := 0; i < NMAX; i++ {
+		if i < matchCount {
+			commentsInput[i] = tempComments[i]
+		} else {
+			commentsInput[i] = Comment{}
+		}
+	}
+
+	return nil
+}
+
+// GetCommentsRanked searches the inverted index for comments containing any word of the search
+// and ranks them by their BM25 score, most relevant first. Comments with the same score keep
+// their order by ID. Only the postings of the search words are visited, not every comment.
+func GetCommentsRanked(commentsInput *[NMAX]Comment, search string) error {
+	var searchTokens [NTOKEN]string
+	var nSearch int
+	var skorDoc [NMAX]float64
+	var tempComments [NMAX]Comment
+	var skor [NMAX]float64
+	var matchCount int
+
+	if nComment == 0 {
+		return fmt.Errorf("tidak ada komentar yang tersedia")
+	}
+
+	tokenize(search, &searchTokens, &nSearch)
+	if nSearch == 0 {
+		return fmt.Errorf("kata kunci tidak boleh kosong")
+	}
+
+	rataPanjang := safeDivide(float64(totalPanjangDoc), float64(nIndexDoc))
+
+	for s := 0; s < nSearch; s++ {
+		var pos, d int
+
+		if isRepeatedToken(&searchTokens, s) || !findIndexTerm(searchTokens[s], &pos) {
+			continue
+		}
+
+		term := &indexTerms[indexUrut[pos]]
+		df := float64(term.nPosting)
+		idf := math.Log((float64(nIndexDoc)-df+0.5)/(df+0.5) + 1)
+
+		for p := 0; p < term.nPosting; p++ {
+			if !findIndexDoc(term.postings[p].commentId, &d) {
+				continue
+			}
+
+			tf := float64(term.postings[p].tf)
+			norm := 1 - bm25B + bm25B*safeDivide(float64(indexDocs[d].panjang), rataPanjang)
+			skorDoc[d] += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+		}
+	}
+
+	for d := 0; d < nIndexDoc; d++ {
+		var comment Comment
+
+		if skorDoc[d] <= 0 || FindCommentById(indexDocs[d].commentId, &comment) != nil {
+			continue
+		}
+
+		j := matchCount - 1
+		for j >= 0 && skor[j] < skorDoc[d] {
+			tempComments[j+1] = tempComments[j]
+			skor[j+1] = skor[j]
+			j--
+		}
+		tempComments[j+1] = comment
+		skor[j+1] = skorDoc[d]
+		matchCount++
+	}
+
+	if matchCount == 0 {
+		return fmt.Errorf("tidak ada komentar yang sesuai dengan pencarian")
+	}
+
+	for i := 0; i < NMAX; i++ {
+		if i < matchCount {
+			commentsInput[i] = tempComments[i]
+		} else {
+			commentsInput[i] = Comment{}
+		}
+	}
+
+	return nil
+}
+
+// IndexComment adds the tokens of a comment to the inverted index. Every distinct token gets
+// one posting with its number of occurrences, inserted in comment ID order. Tokens that do not
+// fit in the NVOCAB terms of the index are left out.
+func IndexComment(comment Comment) {
+	var tokens [NTOKEN]string
+	var nToken, d int
+
+	tokenize(comment.komentar, &tokens, &nToken)
+
+	if findIndexDoc(comment.id, &d) || nIndexDoc >= NMAX {
+		return
+	}
+	for j := nIndexDoc; j > d; j-- {
+		indexDocs[j] = indexDocs[j-1]
+	}
+	indexDocs[d] = IndexDoc{commentId: comment.id, panjang: nToken}
+	nIndexDoc++
+	totalPanjangDoc += nToken
+
+	for t := 0; t < nToken; t++ {
+		var pos, p int
+
+		if isRepeatedToken(&tokens, t) {
+			continue
+		}
+
+		if !findIndexTerm(tokens[t], &pos) {
+			if nIndexTerm >= NVOCAB {
+				continue
+			}
+
+			for j := nIndexTerm; j > pos; j-- {
+				indexUrut[j] = indexUrut[j-1]
+			}
+			indexUrut[pos] = nIndexTerm
+			indexTerms[nIndexTerm] = IndexTerm{term: tokens[t]}
+			nIndexTerm++
+		}
+
+		term := &indexTerms[indexUrut[pos]]
+		if term.nPosting >= NMAX || findPosting(term, comment.id, &p) {
+			continue
+		}
+
+		tf := 1
+		for k := t + 1; k < nToken; k++ {
+			if tokens[k] == tokens[t] {
+				tf++
+			}
+		}
+
+		for j := term.nPosting; j > p; j-- {
+			term.postings[j] = term.postings[j-1]
+		}
+		term.postings[p] = Posting{commentId: comment.id, tf: tf}
+		term.nPosting++
+	}
+}
+
+// UnindexComment removes a comment from the inverted index. It must be called with the
+// comment text that was indexed, before the text is changed or the comment is deleted.
+// Terms left without postings are removed so their place can be used by new words.
+func UnindexComment(comment Comment) {
+	var tokens [NTOKEN]string
+	var nToken, d int
+
+	tokenize(comment.komentar, &tokens, &nToken)
+
+	for t := 0; t < nToken; t++ {
+		var pos, p int
+
+		if isRepeatedToken(&tokens, t) || !findIndexTerm(tokens[t], &pos) {
+			continue
+		}
+
+		term := &indexTerms[indexUrut[pos]]
+		if !findPosting(term, comment.id, &p) {
+			continue
+		}
+
+		for j := p; j < term.nPosting-1; j++ {
+			term.postings[j] = term.postings[j+1]
+		}
+		term.postings[term.nPosting-1] = Posting{}
+		term.nPosting--
+
+		if term.nPosting == 0 {
+			removeIndexTerm(pos)
+		}
+	}
+
+	if findIndexDoc(comment.id, &d) {
+		totalPanjangDoc -= indexDocs[d].panjang
+		for j := d; j < nIndexDoc-1; j++ {
+			indexDocs[j] = indexDocs[j+1]
+		}
+		indexDocs[nIndexDoc-1] = IndexDoc{}
+		nIndexDoc--
+	}
+}
+
+// removeIndexTerm removes the term at position pos of indexUrut. The last term of indexTerms is
+// moved into the freed place so the array stays without gaps.
+func removeIndexTerm(pos int) {
+	var q int
+
+	k := indexUrut[pos]
+	for j := pos; j < nIndexTerm-1; j++ {
+		indexUrut[j] = indexUrut[j+1]
+	}
+	nIndexTerm--
+
+	last := nIndexTerm
+	if k != last {
+		findIndexTerm(indexTerms[last].term, &q)
+		indexTerms[k] = indexTerms[last]
+		indexUrut[q] = k
+	}
+	indexTerms[last] = IndexTerm{}
+}
+
+// findIndexTerm searches the alphabetical term order with binary search. It reports whether
+// the term is in the index and sets pos to its place in indexUrut, or to the place where it
+// would be inserted.
+func findIndexTerm(term string, pos *int) bool {
+	var left, right, mid int
+
+	left = 0
+	right = nIndexTerm - 1
+
+	for left <= right {
+		mid = (left + right) / 2
+
+		if indexTerms[indexUrut[mid]].term == term {
+			*pos = mid
+			return true
+		}
+
+		if indexTerms[indexUrut[mid]].term < term {
+			left = mid + 1
+		} else {
+			right = mid - 1
+		}
+	}
+
+	*pos = left
+	return false
+}
+
+// findIndexDoc searches indexDocs with binary search. It reports whether the comment is indexed
+// and sets pos to its place, or to the place where it would be inserted.
+func findIndexDoc(commentId int, pos *int) bool {
+	var left, right, mid int
+
+	left = 0
+	right = nIndexDoc - 1
+
+	for left <= right {
+		mid = (left + right) / 2
+
+		if indexDocs[mid].commentId == commentId {
+			*pos = mid
+			return true
+		}
+
+		if indexDocs[mid].commentId < commentId {
+			left = mid + 1
+		} else {
+			right = mid - 1
+		}
+	}
+
+	*pos = left
+	return false
+}
+
+// findPosting searches the postings of a term with binary search. It reports whether the comment
+// has a posting and sets pos to its place, or to the place where it would be inserted.
+func findPosting(term *IndexTerm, commentId int, pos *int) bool {
+	var left, right, mid int
+
+	left = 0
+	right = term.nPosting - 1
+
+	for left <= right {
+		mid = (left + right) / 2
+
+		if term.postings[mid].commentId == commentId {
+			*pos = mid
+			return true
+		}
+
+		if term.postings[mid].commentId < commentId {
+			left = mid + 1
+		} else {
+			right = mid - 1
+		}
+	}
+
+	*pos = left
+	return false
+}
+
+// isRepeatedToken reports whether the token at index t already occurred earlier in the array.
+func isRepeatedToken(tokens *[NTOKEN]string, t int) bool {
+	for k := 0; k < t; k++ {
+		if tokens[k] == tokens[t] {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCommentsRegex searches for comments containing at least one match of a compiled regular expression.
+// The matching comments are copied in ID order.
+func GetCommentsRegex(commentsInput *[NMAX]Comment, re *Regex) error {
+	var matchCount int
+
+	if nComment == 0 {
+		return fmt.Errorf("tidak ada komentar yang tersedia")
+	}
+
+	for i := 0; i < nComment; i++ {
+		if CountRegexMatches(re, comments[i].komentar) > 0 {
+			commentsInput[matchCount] = comments[i]
+			matchCount++
+		}
+	}
+
+	if matchCount == 0 {
+		return fmt.Errorf("tidak ada komentar yang cocok dengan pola")
+	}
+
+	for i := matchCount; i < NMAX; i++ {
+		commentsInput[i] = Comment{}
+	}
+
+	return nil
+}
+
+// GetUsersRegex searches for users whose username contains at least one match of a compiled regular expression.
+// The matching users are copied in ID order.
+func GetUsersRegex(usersInput *[NMAX]User, re *Regex) error {
+	var matchCount int
+
+	if nUser == 0 {
+		return fmt.Errorf("tidak ada pengguna yang terdaftar")
+	}
+
+	for i := 0; i < nUser; i++ {
+		if CountRegexMatches(re, users[i].username) > 0 {
+			usersInput[matchCount] = users[i]
+			matchCount++
+		}
+	}
+
+	if matchCount == 0 {
+		return fmt.Errorf("tidak ada username yang cocok dengan pola")
+	}
+
+	for i := matchCount; i < NMAX; i++ {
+		usersInput[i] = User{}
+	}
+
+	return nil
+}
+
+// CompileRegex compiles a regular expression into an automaton. The supported syntax is
+// literal characters, . for any character, classes such as [abc], [a-z] and [^0-9], the escapes
+// \d, \w, \s, \D, \W, \S, \n and \t, a backslash before punctuation for the literal character,
+// the repetitions *, +, ?, {m}, {m,} and {m,n}, alternation with |, grouping with parentheses,
+// and the anchors ^ and $. A leading (?i) makes letters match regardless of case.
+// Errors report the position of the problem in the pattern.
+func CompileRegex(pola string, re *Regex) error {
+	var p regexParser
+
+	*re = Regex{}
+	p.pola = []rune(pola)
+	p.re = re
+
+	if len(p.pola) > panjangRegexMaks {
+		return fmt.Errorf("pola terlalu panjang, maksimal %d karakter", panjangRegexMaks)
+	}
+
+	if len(p.pola) >= 4 && string(p.pola[:4]) == "(?i)" {
+		re.abaikanKapital = true
+		p.pos = 4
+	}
+
+	if p.pos >= len(p.pola) {
+		return fmt.Errorf("pola kosong")
+	}
+
+	f, err := parseRegexAlt(&p)
+	if err != nil {
+		return err
+	}
+
+	if p.pos < len(p.pola) {
+		return fmt.Errorf("posisi %d: kurung tutup tidak memiliki pasangan kurung buka", p.pos+1)
+	}
+
+	match, err := newRegexState(&p, RegexState{jenis: "match", out: -1, out2: -1})
+	if err != nil {
+		return err
+	}
+	re.states[f.akhir].out = match
+	re.mulai = f.mulai
+
+	return nil
+}
+
+// CountRegexMatches counts the non-overlapping, non-empty matches of a compiled regular expression
+// in a text. Each match is the leftmost-longest one, and the search continues after it.
+// Only the first teksRegexMaks characters of the text are searched, and counting stops after
+// langkahRegexMaks characters have been read.
+func CountRegexMatches(re *Regex, teks string) int {
+	var count, mulai, akhir int
+	var sisa int = langkahRegexMaks
+
+	runes := []rune(teks)
+	if len(runes) > teksRegexMaks {
+		runes = runes[:teksRegexMaks]
+	}
+
+	pos := 0
+	for pos < len(runes) && FindRegexMatch(re, runes, pos, &mulai, &akhir, &sisa) {
+		count++
+		pos = akhir
+	}
+
+	return count
+}
+
+// FindRegexMatch finds the leftmost-longest non-empty match at or after position dari and stores
+// its bounds in mulai and akhir. It reports false when there is no match.
+// sisa is the number of characters that may still be read and is decreased for every character;
+// when it runs out the search stops with the best match found so far.
+// The automaton is run once over the text, following every possible state at the same time.
+// Each active state remembers where its match attempt started; when two attempts reach the same
+// state the earlier one is kept. Each character is therefore read a bounded number of times,
+// so no pattern can cause exponential backtracking.
+func FindRegexMatch(re *Regex, teks []rune, dari int, mulai, akhir *int, sisa *int) bool {
+	var current, next [NREGEX]int
+	var awalCurrent, awalNext [NREGEX]int
+	var nCurrent, nNext int
+	var tanda [NREGEX]int
+	var generasi int = 1
+
+	*mulai = -1
+	*akhir = -1
+
+	for i := dari; ; i++ {
+		if *mulai == -1 {
+			addRegexState(re, teks, re.mulai, i, i, &current, &awalCurrent, &nCurrent, &tanda, generasi)
+		}
+
+		for k := 0; k < nCurrent; k++ {
+			awal := awalCurrent[k]
+			if re.states[current[k]].jenis == "match" && i > awal && (*mulai == -1 || awal < *mulai || (awal == *mulai && i > *akhir)) {
+				*mulai = awal
+				*akhir = i
+			}
+		}
+
+		if i >= len(teks) || (nCurrent == 0 && *mulai != -1) || *sisa <= 0 {
+			break
+		}
+		*sisa--
+
+		generasi++
+		nNext = 0
+		for k := 0; k < nCurrent; k++ {
+			if *mulai != -1 && awalCurrent[k] > *mulai {
+				continue
+			}
+			if regexStateMatches(re, &re.states[current[k]], teks[i]) {
+				addRegexState(re, teks, re.states[current[k]].out, i+1, awalCurrent[k], &next, &awalNext, &nNext, &tanda, generasi)
+			}
+		}
+
+		current, next = next, current
+		awalCurrent, awalNext = awalNext, awalCurrent
+		nCurrent = nNext
+	}
+
+	return *mulai != -1
+}
+
+// addRegexState adds a state to the list of active states, following the states that do not read
+// a character. The anchors ^ and $ are only followed at the start and end of the text.
+// awal is the position where the match attempt started, stored next to every state.
+// tanda records the generation in which each state was added so no state is added twice.
+func addRegexState(re *Regex, teks []rune, s, pos, awal int, list, awalList *[NREGEX]int, n *int, tanda *[NREGEX]int, generasi int) {
+	if s == -1 || tanda[s] == generasi {
+		return
+	}
+	tanda[s] = generasi
+
+	switch re.states[s].jenis {
+	case "eps":
+		addRegexState(re, teks, re.states[s].out, pos, awal, list, awalList, n, tanda, generasi)
+	case "split":
+		addRegexState(re, teks, re.states[s].out, pos, awal, list, awalList, n, tanda, generasi)
+		addRegexState(re, teks, re.states[s].out2, pos, awal, list, awalList, n, tanda, generasi)
+	case "bol":
+		if pos == 0 {
+			addRegexState(re, teks, re.states[s].out, pos, awal, list, awalList, n, tanda, generasi)
+		}
+	case "eol":
+		if pos == len(teks) {
+			addRegexState(re, teks, re.states[s].out, pos, awal, list, awalList, n, tanda, generasi)
+		}
+	default:
+		list[*n] = s
+		awalList[*n] = awal
+		*n++
+	}
+}
+
+// regexStateMatches reports whether a state that reads a character accepts the character c.
+func regexStateMatches(re *Regex, state *RegexState, c rune) bool {
+	switch state.jenis {
+	case "any":
+		return c != '\n'
+	case "rune":
+		if re.abaikanKapital {
+			return lowerRune(c) == lowerRune(state.r)
+		}
+		return c == state.r
+	case "class":
+		found := regexClassContains(state, c)
+		if !found && re.abaikanKapital {
+			found = regexClassContains(state, lowerRune(c)) || regexClassContains(state, upperRune(c))
+		}
+		return found != state.negasi
+	}
+	return false
+}
+
+// regexClassContains reports whether c lies in one of the ranges of a character class.
+func regexClassContains(state *RegexState, c rune) bool {
+	for i := 0; i < state.nRange; i++ {
+		if c >= state.ranges[i][0] && c <= state.ranges[i][1] {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRegexAlt parses alternatives separated by |.
+func parseRegexAlt(p *regexParser) (regexFrag, error) {
+	f, err := parseRegexConcat(p)
+	if err != nil {
+		return f, err
+	}
+
+	for p.pos < len(p.pola) && p.pola[p.pos] == '|' {
+		p.pos++
+
+		g, err := parseRegexConcat(p)
+		if err != nil {
+			return f, err
+		}
+
+		split, err := newRegexState(p, RegexState{jenis: "split", out: f.mulai, out2: g.mulai})
+		if err != nil {
+			return f, err
+		}
+		akhir, err := newRegexState(p, RegexState{jenis: "eps", out: -1, out2: -1})
+		if err != nil {
+			return f, err
+		}
+
+		p.re.states[f.akhir].out = akhir
+		p.re.states[g.akhir].out = akhir
+		f = regexFrag{mulai: split, akhir: akhir}
+	}
+
+	return f, nil
+}
+
+// parseRegexConcat parses a sequence of repeated atoms up to a |, a closing parenthesis, or the end.
+// An empty sequence matches the empty string.
+func parseRegexConcat(p *regexParser) (regexFrag, error) {
+	var f regexFrag
+	var ada bool
+
+	for p.pos < len(p.pola) && p.pola[p.pos] != '|' && p.pola[p.pos] != ')' {
+		g, err := parseRegexPiece(p)
+		if err != nil {
+			return f, err
+		}
+
+		if !ada {
+			f = g
+			ada = true
+		} else {
+			p.re.states[f.akhir].out = g.mulai
+			f.akhir = g.akhir
+		}
+	}
+
+	if !ada {
+		e, err := newRegexState(p, RegexState{jenis: "eps", out: -1, out2: -1})
+		if err != nil {
+			return f, err
+		}
+		f = regexFrag{mulai: e, akhir: e}
+	}
+
+	return f, nil
+}
+
+// parseRegexPiece parses an atom followed by an optional repetition. A {m,n} repetition is built
+// by parsing the atom again for every copy it needs.
+func parseRegexPiece(p *regexParser) (regexFrag, error) {
+	atomMulai := p.pos
+
+	f, err := parseRegexAtom(p)
+	if err != nil || p.pos >= len(p.pola) {
+		return f, err
+	}
+
+	op := p.pola[p.pos]
+	if op != '*' && op != '+' && op != '?' && op != '{' {
+		return f, nil
+	}
+	opPos := p.pos
+
+	min, max := 0, -1
+	switch op {
+	case '*':
+		p.pos++
+	case '+':
+		min = 1
+		p.pos++
+	case '?':
+		max = 1
+		p.pos++
+	case '{':
+		if err := parseRegexRepeat(p, &min, &max); err != nil {
+			return f, err
+		}
+	}
+
+	if p.pos < len(p.pola) && (p.pola[p.pos] == '*' || p.pola[p.pos] == '+' || p.pola[p.pos] == '?' || p.pola[p.pos] == '{') {
+		return f, fmt.Errorf("posisi %d: pengulangan ganda setelah '%c' tidak didukung", p.pos+1, p.pola[opPos])
+	}
+
+	setelah := p.pos
+	copyAtom := func(first bool) (regexFrag, error) {
+		if first {
+			return f, nil
+		}
+		p.pos = atomMulai
+		g, err := parseRegexAtom(p)
+		p.pos = setelah
+		return g, err
+	}
+
+	hasil, err := newRegexState(p, RegexState{jenis: "eps", out: -1, out2: -1})
+	if err != nil {
+		return f, err
+	}
+	result := regexFrag{mulai: hasil, akhir: hasil}
+	first := true
+
+	for i := 0; i < min; i++ {
+		g, err := copyAtom(first)
+		if err != nil {
+			return f, err
+		}
+		first = false
+		p.re.states[result.akhir].out = g.mulai
+		result.akhir = g.akhir
+	}
+
+	n := max - min
+	if max == -1 {
+		n = 1
+	}
+	for i := 0; i < n; i++ {
+		g, err := copyAtom(first)
+		if err != nil {
+			return f, err
+		}
+		first = false
+
+		akhir, err := newRegexState(p, RegexState{jenis: "eps", out: -1, out2: -1})
+		if err != nil {
+			return f, err
+		}
+		split, err := newRegexState(p, RegexState{jenis: "split", out: g.mulai, out2: akhir})
+		if err != nil {
+			return f, err
+		}
+
+		if max == -1 {
+			p.re.states[g.akhir].out = split
+		} else {
+			p.re.states[g.akhir].out = akhir
+		}
+		p.re.states[result.akhir].out = split
+		result.akhir = akhir
+	}
+
+	return result, nil
+}
+
+// parseRegexRepeat parses a {m}, {m,}, or {m,n} repetition starting at the opening brace and
+// leaves the position after the closing brace. max is set to -1 for {m,}.
+func parseRegexRepeat(p *regexParser, min, max *int) error {
+	mulai := p.pos
+	p.pos++
+
+	baca := func() (int, bool) {
+		var n int
+		var ada bool
+		for p.pos < len(p.pola) && p.pola[p.pos] >= '0' && p.pola[p.pos] <= '9' {
+			n = n*10 + int(p.pola[p.pos]-'0')
+			ada = true
+			p.pos++
+			if n > pengulanganRegexMaks {
+				return n, true
+			}
+		}
+		return n, ada
+	}
+
+	m, ok := baca()
+	if !ok {
+		return fmt.Errorf("posisi %d: pengulangan harus berbentuk {m}, {m,}, atau {m,n}", mulai+1)
+	}
+	*min = m
+	*max = m
+
+	if p.pos < len(p.pola) && p.pola[p.pos] == ',' {
+		p.pos++
+		n, ok := baca()
+		if ok {
+			*max = n
+		} else {
+			*max = -1
+		}
+	}
+
+	if p.pos >= len(p.pola) || p.pola[p.pos] != '}' {
+		return fmt.Errorf("posisi %d: pengulangan harus berbentuk {m}, {m,}, atau {m,n}", mulai+1)
+	}
+	p.pos++
+
+	if *min > pengulanganRegexMaks || *max > pengulanganRegexMaks {
+		return fmt.Errorf("posisi %d: jumlah pengulangan maksimal %d", mulai+1, pengulanganRegexMaks)
+	}
+	if *max != -1 && *max < *min {
+		return fmt.Errorf("posisi %d: pada {%d,%d} batas atas lebih kecil dari batas bawah", mulai+1, *min, *max)
+	}
+
+	return nil
+}
+
+// parseRegexAtom parses a single character, character class, escape, anchor, or parenthesized group.
+func parseRegexAtom(p *regexParser) (regexFrag, error) {
+	var state RegexState
+
+	mulai := p.pos
+	c := p.pola[p.pos]
+	p.pos++
+
+	switch c {
+	case '(':
+		f, err := parseRegexAlt(p)
+		if err != nil {
+			return f, err
+		}
+		if p.pos >= len(p.pola) || p.pola[p.pos] != ')' {
+			return f, fmt.Errorf("posisi %d: kurung buka tidak ditutup", mulai+1)
+		}
+		p.pos++
+		return f, nil
+	case '*', '+', '?', '{':
+		return regexFrag{}, fmt.Errorf("posisi %d: '%c' tidak didahului karakter yang dapat diulang", mulai+1, c)
+	case '[':
+		if err := parseRegexClass(p, mulai, &state); err != nil {
+			return regexFrag{}, err
+		}
+	case '.':
+		state = RegexState{jenis: "any"}
+	case '^':
+		state = RegexState{jenis: "bol"}
+	case '$':
+		state = RegexState{jenis: "eol"}
+	case '\\':
+		if p.pos >= len(p.pola) {
+			return regexFrag{}, fmt.Errorf("posisi %d: garis miring terbalik di akhir pola", mulai+1)
+		}
+		e := p.pola[p.pos]
+		p.pos++
+
+		state.jenis = "class"
+		if isClass, _ := addRegexEscapeClass(e, &state); isClass {
+			break
+		}
+		switch e {
+		case 'D', 'W', 'S':
+			addRegexEscapeClass(lowerRune(e), &state)
+			state.negasi = true
+		default:
+			if e >= '1' && e <= '9' {
+				return regexFrag{}, fmt.Errorf("posisi %d: referensi balik \\%c tidak didukung", mulai+1, e)
+			}
+			r, ok := regexEscapeRune(e)
+			if !ok {
+				return regexFrag{}, fmt.Errorf("posisi %d: escape \\%c tidak dikenal", mulai+1, e)
+			}
+			state = RegexState{jenis: "rune", r: r}
+		}
+	default:
+		state = RegexState{jenis: "rune", r: c}
+	}
+
+	akhir, err := newRegexState(p, RegexState{jenis: "eps", out: -1, out2: -1})
+	if err != nil {
+		return regexFrag{}, err
+	}
+	state.out = akhir
+	state.out2 = -1
+
+	s, err := newRegexState(p, state)
+	if err != nil {
+		return regexFrag{}, err
+	}
+
+	return regexFrag{mulai: s, akhir: akhir}, nil
+}
+
+// parseRegexClass parses a character class such as [a-z0-9_] or [^abc] into a "class" state.
+// mulai is the position of the opening bracket, used in error messages.
+func parseRegexClass(p *regexParser, mulai int, state *RegexState) error {
+	*state = RegexState{jenis: "class"}
+
+	if p.pos < len(p.pola) && p.pola[p.pos] == '^' {
+		state.negasi = true
+		p.pos++
+	}
+
+	first := true
+	for p.pos < len(p.pola) && (p.pola[p.pos] != ']' || first) {
+		first = false
+		itemPos := p.pos
+		lo := p.pola[p.pos]
+		p.pos++
+
+		if lo == '\\' {
+			if p.pos >= len(p.pola) {
+				break
+			}
+			e := p.pola[p.pos]
+			p.pos++
+
+			isClass, err := addRegexEscapeClass(e, state)
+			if err != nil {
+				return fmt.Errorf("posisi %d: %s", mulai+1, err.Error())
+			} else if isClass {
+				continue
+			}
+			if e == 'D' || e == 'W' || e == 'S' {
+				return fmt.Errorf("posisi %d: \\%c tidak dapat dipakai di dalam kelas karakter", itemPos+1, e)
+			}
+
+			r, ok := regexEscapeRune(e)
+			if !ok {
+				return fmt.Errorf("posisi %d: escape \\%c tidak dikenal", itemPos+1, e)
+			}
+			lo = r
+		}
+
+		hi := lo
+		if p.pos+1 < len(p.pola) && p.pola[p.pos] == '-' && p.pola[p.pos+1] != ']' {
+			hi = p.pola[p.pos+1]
+			p.pos += 2
+
+			if hi == '\\' {
+				if p.pos >= len(p.pola) {
+					break
+				}
+				r, ok := regexEscapeRune(p.pola[p.pos])
+				if !ok {
+					return fmt.Errorf("posisi %d: escape \\%c tidak dapat menjadi batas rentang", p.pos, p.pola[p.pos])
+				}
+				hi = r
+				p.pos++
+			}
+
+			if hi < lo {
+				return fmt.Errorf("posisi %d: rentang %c-%c tidak valid, karakter awal harus lebih kecil", itemPos+1, lo, hi)
+			}
+		}
+
+		if state.nRange >= NRANGE {
+			return fmt.Errorf("posisi %d: kelas karakter terlalu besar, maksimal %d rentang", mulai+1, NRANGE)
+		}
+		state.ranges[state.nRange] = [2]rune{lo, hi}
+		state.nRange++
+	}
+
+	if p.pos >= len(p.pola) {
+		return fmt.Errorf("posisi %d: kelas karakter tidak ditutup dengan ]", mulai+1)
+	}
+	p.pos++
+
+	return nil
+}
+
+// addRegexEscapeClass adds the ranges of the class escapes \d, \w, and \s to a character class.
+// It reports false when e is not one of them, and an error when the class has no room left.
+func addRegexEscapeClass(e rune, state *RegexState) (bool, error) {
+	var ranges [4][2]rune
+	var n int
+
+	switch e {
+	case 'd':
+		ranges[0] = [2]rune{'0', '9'}
+		n = 1
+	case 'w':
+		ranges = [4][2]rune{{'a', 'z'}, {'A', 'Z'}, {'0', '9'}, {'_', '_'}}
+		n = 4
+	case 's':
+		ranges = [4][2]rune{{' ', ' '}, {'\t', '\n'}, {'\r', '\r'}}
+		n = 3
+	default:
+		return false, nil
+	}
+
+	if state.nRange+n > NRANGE {
+		return true, fmt.Errorf("kelas karakter terlalu besar, maksimal %d rentang", NRANGE)
+	}
+
+	for i := 0; i < n; i++ {
+		state.ranges[state.nRange] = ranges[i]
+		state.nRange++
+	}
+
+	return true, nil
+}
+
+// regexEscapeRune returns the character written by an escape: \n and \t for newline and tab,
+// and a backslash before any character that is not a letter or digit for that character itself.
+func regexEscapeRune(e rune) (rune, bool) {
+	switch {
+	case e == 'n':
+		return '\n', true
+	case e == 't':
+		return '\t', true
+	case (e >= 'a' && e <= 'z') || (e >= 'A' && e <= 'Z') || (e >= '0' && e <= '9'):
+		return 0, false
+	}
+	return e, true
+}
+
+// newRegexState appends a state to the automaton being built and returns its index.
+func newRegexState(p *regexParser, state RegexState) (int, error) {
+	if p.re.nState >= NREGEX {
+		return -1, fmt.Errorf("pola terlalu kompleks, maksimal %d state", NREGEX)
+	}
+
+	p.re.states[p.re.nState] = state
+	p.re.nState++
+	return p.re.nState - 1, nil
+}
+
+// SetFuzzyDistance changes the maximum edit distance used by fuzzy search.
+func SetFuzzyDistance(jarak int) error {
+	if jarak < 0 || jarak > jarakFuzzyBatas {
+		return fmt.Errorf("jarak maksimum harus antara 0 dan %d", jarakFuzzyBatas)
+	}
+
+	jarakFuzzyMaks = jarak
+	return nil
+}
+
+// DamerauLevenshtein returns the edit distance between two strings, counting insertions, deletions,
+// substitutions, and swaps of two adjacent characters as one edit each. It uses the optimal string
+// alignment variant, in which a swapped pair is not edited again. Only the last three rows of the
+// distance table are kept.
+func DamerauLevenshtein(a, b string) int {
+	ra := []rune(a)
+	rb := []rune(b)
+
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := 0; j <= len(rb); j++ {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			biaya := 1
+			if ra[i-1] == rb[j-1] {
+				biaya = 0
+			}
+
+			curr[j] = prev[j] + 1
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+			if prev[j-1]+biaya < curr[j] {
+				curr[j] = prev[j-1] + biaya
+			}
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] && prev2[j-2]+1 < curr[j] {
+				curr[j] = prev2[j-2] + 1
+			}
+		}
+
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	return prev[len(rb)]
+}
+
+// ParseQuery parses a search query into a syntax tree.
+// Words separated by spaces must all match; AND, OR, and NOT (in capitals) combine conditions,
+// with NOT binding tightest and OR loosest, and parentheses group them.
+// A quoted "phrase" matches consecutive text, * and ? in a word match any run of characters
+// and any single character, field:value filters on kategori, user, status, platform, post, or handle,
+// and len>N, len<N, len>=N, len<=N, or len=N compare the number of characters.
+// Other words containing a colon, such as links, are searched as plain words.
+// Syntax errors report the position of the offending token.
+func ParseQuery(input string, query *Query) error {
+	var p queryParser
+
+	*query = Query{}
+	p.query = query
+
+	if err := tokenizeQuery(input, &p.tokens, &p.nToken); err != nil {
+		return err
+	}
+
+	if p.nToken == 0 {
+		return fmt.Errorf("query kosong")
+	}
+
+	akar, err := parseQueryOr(&p)
+	if err != nil {
+		return err
+	}
+
+	if p.pos < p.nToken {
+		t := p.tokens[p.pos]
+		if t.jenis == ")" {
+			return fmt.Errorf("posisi %d: kurung tutup tidak memiliki pasangan kurung buka", t.posisi)
+		}
+		return fmt.Errorf("posisi %d: token '%s' tidak terduga", t.posisi, queryTokenText(t))
+	}
+
+	query.akar = akar
+	return nil
+}
+
+// EvaluateQuery reports whether a comment matches a parsed search query.
+func EvaluateQuery(query *Query, comment Comment) bool {
+	var tokens [NTOKEN]string
+	var nToken int
+
+	teks := []rune(normalizeEmoji(toLower(comment.komentar)))
+	tokenize(comment.komentar, &tokens, &nToken)
+
+	return evaluateQueryNode(query, query.akar, comment, teks, &tokens, nToken)
+}
+
+// evaluateQueryNode evaluates the node at index i of the syntax tree against a comment.
+// teks is the lowercased comment text and tokens are its words, computed once per comment.
+func evaluateQueryNode(query *Query, i int, comment Comment, teks []rune, tokens *[NTOKEN]string, nToken int) bool {
+	node := query.nodes[i]
+
+	switch node.jenis {
+	case "and":
+		return evaluateQueryNode(query, node.kiri, comment, teks, tokens, nToken) && evaluateQueryNode(query, node.kanan, comment, teks, tokens, nToken)
+	case "or":
+		return evaluateQueryNode(query, node.kiri, comment, teks, tokens, nToken) || evaluateQueryNode(query, node.kanan, comment, teks, tokens, nToken)
+	case "not":
+		return !evaluateQueryNode(query, node.kiri, comment, teks, tokens, nToken)
+	case "frasa":
+		return containsRunes(teks, []rune(node.nilai))
+	case "kata":
+		if !hasWildcard(node.nilai) {
+			return containsRunes(teks, []rune(node.nilai))
+		}
+		for t := 0; t < nToken; t++ {
+			if matchWildcard([]rune(tokens[t]), []rune(node.nilai)) {
+				return true
+			}
+		}
+		return false
+	case "field":
+		switch node.field {
+		case "kategori":
+			return effectiveKategori(comment) == node.nilai
+		case "user":
+			if node.op == "id" {
+				return comment.userId == node.angka
+			}
+			nama, ada := authorName(comment)
+			return ada && matchWildcard([]rune(toLower(nama)), []rune(node.nilai))
+		case "status":
+			return comment.status == node.nilai
+		case "platform":
+			return matchSourceField(comment.sumber.platform, node.nilai)
+		case "post":
+			return matchSourceField(comment.sumber.postId, node.nilai)
+		case "handle":
+			return matchSourceField(comment.sumber.handle, node.nilai)
+		}
+	case "len":
+		panjang := len([]rune(comment.komentar))
+		switch node.op {
+		case ">":
+			return panjang > node.angka
+		case "<":
+			return panjang < node.angka
+		case ">=":
+			return panjang >= node.angka
+		case "<=":
+			return panjang <= node.angka
+		case "=":
+			return panjang == node.angka
+		}
+	}
+
+	return false
+}
+
+// tokenizeQuery splits a search query into words, quoted phrases, parentheses, and the
+// operators AND, OR, and NOT. Emoticons such as :) and :( are kept as words even though they
+// contain parentheses. It returns an error for an unclosed quote or an empty phrase.
+func tokenizeQuery(input string, tokens *[NQUERY]QueryToken, n *int) error {
+	runes := []rune(input)
+	*n = 0
+
+	i := 0
+	for i < len(runes) {
+		if runes[i] == ' ' || runes[i] == '\t' {
+			i++
+			continue
+		}
+
+		if *n >= NQUERY-1 {
+			return fmt.Errorf("query terlalu panjang, maksimal %d token", NQUERY-1)
+		}
+
+		start := i
+		emoticon := matchEmoticon(runes, i)
+
+		switch {
+		case emoticon > 0:
+			tokens[*n] = QueryToken{jenis: "kata", teks: string(runes[i : i+emoticon]), posisi: start + 1}
+			i += emoticon
+		case runes[i] == '(' || runes[i] == ')':
+			tokens[*n] = QueryToken{jenis: string(runes[i]), posisi: start + 1}
+			i++
+		case runes[i] == '"':
+			i++
+			for i < len(runes) && runes[i] != '"' {
+				i++
+			}
+			if i >= len(runes) {
+				return fmt.Errorf("posisi %d: tanda kutip tidak ditutup", start+1)
+			}
+			frasa := toLower(string(runes[start+1 : i]))
+			if frasa == "" {
+				return fmt.Errorf("posisi %d: frasa dalam tanda kutip kosong", start+1)
+			}
+			tokens[*n] = QueryToken{jenis: "frasa", teks: frasa, posisi: start + 1}
+			i++
+		default:
+			for i < len(runes) && runes[i] != ' ' && runes[i] != '\t' && runes[i] != '(' && runes[i] != ')' && runes[i] != '"' {
+				i++
+			}
+			kata := string(runes[start:i])
+			if kata == "AND" || kata == "OR" || kata == "NOT" {
+				tokens[*n] = QueryToken{jenis: kata, posisi: start + 1}
+			} else {
+				tokens[*n] = QueryToken{jenis: "kata", teks: kata, posisi: start + 1}
+			}
+		}
+		*n++
+	}
+
+	return nil
+}
+
+// parseQueryOr parses a sequence of AND expressions separated by OR.
+func parseQueryOr(p *queryParser) (int, error) {
+	kiri, err := parseQueryAnd(p)
+	if err != nil {
+		return -1, err
+	}
+
+	for p.pos < p.nToken && p.tokens[p.pos].jenis == "OR" {
+		op := p.tokens[p.pos]
+		p.pos++
+
+		if !startsQueryOperand(p) {
+			return -1, missingOperandError(p, op)
+		}
+
+		kanan, err := parseQueryAnd(p)
+		if err != nil {
+			return -1, err
+		}
+
+		if kiri, err = addQueryNode(p, QueryNode{jenis: "or", kiri: kiri, kanan: kanan}); err != nil {
+			return -1, err
+		}
+	}
+
+	return kiri, nil
+}
+
+// parseQueryAnd parses a sequence of unary expressions joined by AND or by plain spaces.
+func parseQueryAnd(p *queryParser) (int, error) {
+	kiri, err := parseQueryUnary(p)
+	if err != nil {
+		return -1, err
+	}
+
+	for p.pos < p.nToken {
+		if p.tokens[p.pos].jenis == "AND" {
+			op := p.tokens[p.pos]
+			p.pos++
+
+			if !startsQueryOperand(p) {
+				return -1, missingOperandError(p, op)
+			}
+		} else if !startsQueryOperand(p) {
+			break
+		}
+
+		kanan, err := parseQueryUnary(p)
+		if err != nil {
+			return -1, err
+		}
+
+		if kiri, err = addQueryNode(p, QueryNode{jenis: "and", kiri: kiri, kanan: kanan}); err != nil {
+			return -1, err
+		}
+	}
+
+	return kiri, nil
+}
+
+// parseQueryUnary parses an expression that may be preceded by NOT.
+func parseQueryUnary(p *queryParser) (int, error) {
+	if p.pos < p.nToken && p.tokens[p.pos].jenis == "NOT" {
+		op := p.tokens[p.pos]
+		p.pos++
+
+		if !startsQueryOperand(p) {
+			return -1, missingOperandError(p, op)
+		}
+
+		operand, err := parseQueryUnary(p)
+		if err != nil {
+			return -1, err
+		}
+
+		return addQueryNode(p, QueryNode{jenis: "not", kiri: operand, kanan: -1})
+	}
+
+	return parseQueryPrimary(p)
+}
+
+// parseQueryPrimary parses a parenthesized expression, a quoted phrase, or a single word,
+// turning field:value and len comparisons into filter nodes.
+func parseQueryPrimary(p *queryParser) (int, error) {
+	if p.pos >= p.nToken {
+		return -1, fmt.Errorf("query tidak lengkap")
+	}
+
+	t := p.tokens[p.pos]
+	p.pos++
+
+	switch t.jenis {
+	case "AND", "OR":
+		return -1, fmt.Errorf("posisi %d: operator %s tidak memiliki kata kunci di sebelah kiri", t.posisi, t.jenis)
+	case ")":
+		return -1, fmt.Errorf("posisi %d: kurung tutup tidak memiliki pasangan kurung buka", t.posisi)
+	case "(":
+		if p.pos < p.nToken && p.tokens[p.pos].jenis == ")" {
+			return -1, fmt.Errorf("posisi %d: kurung kosong", t.posisi)
+		}
+
+		inner, err := parseQueryOr(p)
+		if err != nil {
+			return -1, err
+		}
+
+		if p.pos >= p.nToken || p.tokens[p.pos].jenis != ")" {
+			return -1, fmt.Errorf("posisi %d: kurung buka tidak ditutup", t.posisi)
+		}
+		p.pos++
+		return inner, nil
+	case "frasa":
+		return addQueryNode(p, QueryNode{jenis: "frasa", nilai: normalizeEmoji(t.teks), kiri: -1, kanan: -1})
+	}
+
+	kata := t.teks
+
+	if len(kata) > 3 && toLower(kata[:3]) == "len" && (kata[3] == '<' || kata[3] == '>' || kata[3] == '=') {
+		op := kata[3:4]
+		angka := kata[4:]
+		if len(angka) > 0 && angka[0] == '=' && op != "=" {
+			op += "="
+			angka = angka[1:]
+		}
+
+		nilai, ok := parseNumber(angka)
+		if !ok {
+			return -1, fmt.Errorf("posisi %d: nilai pada '%s' harus berupa bilangan bulat", t.posisi, kata)
+		}
+		return addQueryNode(p, QueryNode{jenis: "len", op: op, angka: nilai, kiri: -1, kanan: -1})
+	}
+
+	titikDua := -1
+	for i := 0; i < len(kata) && titikDua == -1; i++ {
+		if kata[i] == ':' {
+			titikDua = i
+		}
+	}
+
+	known := false
+	if titikDua > 0 {
+		for i := 0; i < len(queryFields); i++ {
+			if queryFields[i] == toLower(kata[:titikDua]) {
+				known = true
+			}
+		}
+	}
+
+	if known {
+		field := toLower(kata[:titikDua])
+		nilai := toLower(kata[titikDua+1:])
+
+		if nilai == "" {
+			return -1, fmt.Errorf("posisi %d: nilai untuk field '%s' kosong", t.posisi, field)
+		}
+
+		node := QueryNode{jenis: "field", field: field, nilai: nilai, kiri: -1, kanan: -1}
+		switch field {
+		case "kategori":
+			if kategoriIndex(nilai) == -1 {
+				return -1, fmt.Errorf("posisi %d: kategori '%s' tidak dikenal, gunakan positif, netral, atau negatif", t.posisi, nilai)
+			}
+		case "status":
+			if statusIndex(nilai) == -1 {
+				return -1, fmt.Errorf("posisi %d: status '%s' tidak dikenal, gunakan pending, approved, hidden, atau removed", t.posisi, nilai)
+			}
+		case "user":
+			if id, ok := parseNumber(nilai); ok {
+				node.op = "id"
+				node.angka = id
+			} else {
+				node.nilai = toLower(nilai)
+			}
+		}
+		return addQueryNode(p, node)
+	}
+
+	return addQueryNode(p, QueryNode{jenis: "kata", nilai: normalizeEmoji(toLower(kata)), kiri: -1, kanan: -1})
+}
+
+// addQueryNode appends a node to the query being parsed and returns its index.
+func addQueryNode(p *queryParser, node QueryNode) (int, error) {
+	if p.query.nNode >= NQUERY {
+		return -1, fmt.Errorf("query terlalu kompleks, maksimal %d bagian", NQUERY)
+	}
+
+	p.query.nodes[p.query.nNode] = node
+	p.query.nNode++
+	return p.query.nNode - 1, nil
+}
+
+// startsQueryOperand reports whether the next token can start an operand.
+func startsQueryOperand(p *queryParser) bool {
+	if p.pos >= p.nToken {
+		return false
+	}
+
+	jenis := p.tokens[p.pos].jenis
+	return jenis == "kata" || jenis == "frasa" || jenis == "(" || jenis == "NOT"
+}
+
+// missingOperandError describes an operator that is not followed by an operand.
+func missingOperandError(p *queryParser, op QueryToken) error {
+	if p.pos >= p.nToken {
+		return fmt.Errorf("posisi %d: operator %s tidak diikuti kata kunci", op.posisi, op.jenis)
+	}
+
+	t := p.tokens[p.pos]
+	return fmt.Errorf("posisi %d: operator %s diikuti '%s', seharusnya kata kunci", t.posisi, op.jenis, queryTokenText(t))
+}
+
+// queryTokenText returns the text of a token as it appeared in the query.
+func queryTokenText(t QueryToken) string {
+	switch t.jenis {
+	case "kata":
+		return t.teks
+	case "frasa":
+		return "\"" + t.teks + "\""
+	}
+	return t.jenis
+}
+
+// GetCommentsSort sorts the comments shown in the listing, the entries of commentsInput up to the first
+// empty one, in place using the keys, directions and algorithm of spec, so search results stay a search
+// result after sorting. The work done is counted in stats.
+func GetCommentsSort(commentsInput *[NMAX]Comment, spec SortSpec, stats *SortStats) error {
+	var n int
+	var key Comment
+
+	for n < NMAX && commentsInput[n].id != 0 {
+		n++
+	}
+	if n == 0 {
+		return fmt.Errorf("tidak ada komentar yang tersedia")
+	}
+
+	if err := ValidateSortSpec(spec, kunciSortKomentar[:]); err != nil {
+		return err
+	}
+
+	*stats = SortStats{}
+
+	if spec.algoritma == "selection" {
+		for i := 0; i < n-1; i++ {
+			minIdx := i
+			for j := i + 1; j < n; j++ {
+				if compareComments(commentsInput[j], commentsInput[minIdx], spec, stats) < 0 {
+					minIdx = j
+				}
+			}
+
+			if minIdx != i {
+				commentsInput[i], commentsInput[minIdx] = commentsInput[minIdx], commentsInput[i]
+				stats.pertukaran++
+			}
+		}
+	} else {
+		for i := 1; i < n; i++ {
+			key = commentsInput[i]
+			j := i - 1
+
+			for j >= 0 && compareComments(commentsInput[j], key, spec, stats) > 0 {
+				commentsInput[j+1] = commentsInput[j]
+				stats.pertukaran++
+				j--
+			}
+
+			commentsInput[j+1] = key
+		}
+	}
+
+	return nil
+}
+
+// compareComments compares two comments on the keys of spec in order of priority and counts the comparison.
+// Authors are compared by username, or by source handle for imported comments, ignoring case;
+// authors that are no longer known come first. Sentiment runs from positive to negative.
+// It returns a negative number when a comes first, a positive number when b comes first, and 0 when
+// they are the same comment.
+func compareComments(a, b Comment, spec SortSpec, stats *SortStats) int {
+	stats.perbandingan++
+
+	for k := 0; k < spec.nKunci; k++ {
+		var hasil int
+
+		switch spec.kunci[k] {
+		case "id":
+			hasil = a.id - b.id
+		case "penulis":
+			namaA, _ := authorName(a)
+			namaB, _ := authorName(b)
+			hasil = compareText(toLower(namaA), toLower(namaB))
+		case "tanggal":
+			hasil = a.dibuat.Compare(b.dibuat)
+		case "sentimen":
+			hasil = kategoriIndex(effectiveKategori(a)) - kategoriIndex(effectiveKategori(b))
+		case "panjang":
+			hasil = len([]rune(a.komentar)) - len([]rune(b.komentar))
+		}
+
+		if spec.menurun[k] {
+			hasil = -hasil
+		}
+		if hasil != 0 {
+			return hasil
+		}
+	}
+
+	return a.id - b.id
+}
+
+// ValidateSortSpec checks that a sort has between one and NSORTKEY keys taken from kunci,
+// and a known algorithm.
+func ValidateSortSpec(spec SortSpec, kunci []string) error {
+	if spec.nKunci < 1 || spec.nKunci > NSORTKEY {
+		return fmt.Errorf("jumlah kunci sortir harus antara 1 dan %d", NSORTKEY)
+	}
+
+	for k := 0; k < spec.nKunci; k++ {
+		found := false
+		for i := 0; i < len(kunci); i++ {
+			if kunci[i] == spec.kunci[k] {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("kunci sortir '%s' tidak dikenal", spec.kunci[k])
+		}
+	}
+
+	if spec.algoritma != algoritmaSort[0] && spec.algoritma != algoritmaSort[1] {
+		return fmt.Errorf("algoritma sortir '%s' tidak dikenal", spec.algoritma)
+	}
+
+	return nil
+}
+
+// CreateReply adds a comment as a reply to the comment with the ID parentId.
+// The reply goes through the same checks and analysis as CreateComment.
+func CreateReply(user User, parentId int, komentar string) error {
+	var parent Comment
+
+	if err := FindCommentById(parentId, &parent); err != nil {
+		return err
+	}
+
+	if parent.status == "removed" {
+		return fmt.Errorf("komentar dengan ID %d sudah dihapus dan tidak dapat dibalas", parentId)
+	}
+
+	if err := CreateComment(user, komentar, 0); err != nil {
+		return err
+	}
+
+	comments[nComment-1].parentId = parentId
+	return nil
+}
+
+// AnalyzeThreadSentiment computes the sentiment of the thread starting at the comment with the ID rootId.
+// Like the thread view, only approved replies and the approved replies below them are included, so
+// moderated content does not leak through the aggregate; a removed root is not counted either. Because
+// a reply always has a larger ID than the comment it replies to, one pass in ID order finds the whole thread.
+func AnalyzeThreadSentiment(rootId int, thread *ThreadSentiment) error {
+	var anggota [NMAX]int
+	var nAnggota int
+	var total float64
+	var result SentimentResult
+
+	*thread = ThreadSentiment{}
+
+	for i := 0; i < nComment; i++ {
+		inThread := comments[i].id == rootId
+		for j := 0; j < nAnggota && !inThread; j++ {
+			inThread = comments[i].parentId == anggota[j]
+		}
+
+		if !inThread || (comments[i].id != rootId && comments[i].status != "approved") {
+			continue
+		}
+
+		anggota[nAnggota] = comments[i].id
+		nAnggota++
+
+		if comments[i].status == "removed" {
+			continue
+		}
+
+		AnalyzeSentiment(comments[i].komentar, &result)
+		total += result.skor
+		thread.jumlah++
+
+		k := kategoriIndex(effectiveKategori(comments[i]))
+		if k != -1 {
+			thread.perKategori[k]++
+		}
+	}
+
+	if nAnggota == 0 {
+		return fmt.Errorf("komentar dengan ID %d tidak ditemukan", rootId)
+	}
+
+	thread.skor = safeDivide(total, float64(thread.jumlah))
+	thread.kategori = kategoriFromScore(thread.skor)
+	return nil
+}
+
+// FindCommentById searches for a comment with the specified ID using binary search.
+// It assumes that the comments array is sorted by ID in ascending order.
+// If found, it copies the comment data to the provided comment pointer.
+func FindCommentById(id int, comment *Comment) error {
+	var langkah int
+
+	i := findCommentIndex(id, &langkah)
+	if i == -1 {
+		return fmt.Errorf("komentar dengan ID %d tidak ditemukan", id)
+	}
+
+	*comment = comments[i]
+	return nil
+}
+
+// findCommentIndex returns the index of the comment with the specified ID using binary search, or -1
+// when there is none. Every probe of the array is counted in langkah.
+func findCommentIndex(id int, langkah *int) int {
+	var left, right, mid int
+
+	left = 0
+	right = nComment - 1
+
+	for left <= right {
+		mid = (left + right) / 2
+		*langkah++
+
+		if comments[mid].id == id {
+			return mid
+		}
+
+		if comments[mid].id < id {
+			left = mid + 1
+		} else {
+			right = mid - 1
+		}
+	}
+
+	return -1
+}
+
+// GetReviewQueue collects the comments that need review into the provided array.
+// A comment needs review when its category has not been confirmed and the confidence
+// of its prediction is below ambangKeyakinan.
+func GetReviewQueue(commentsInput *[NMAX]Comment, n *int) {
+	*n = 0
+	for i := 0; i < nComment; i++ {
+		if comments[i].kategoriKonfirmasi == "" && comments[i].keyakinan < ambangKeyakinan {
+			commentsInput[*n] = comments[i]
+			*n++
+		}
+	}
+}
+
+// ConfirmCommentLabel stores the category confirmed by a moderator using binary search to find the comment.
+// It assumes that the comments array is sorted by ID in ascending order.
+func ConfirmCommentLabel(id int, kategori string) error {
+	var left, right, mid int
+
+	if kategoriIndex(kategori) == -1 {
+		return fmt.Errorf("kategori harus 'positif', 'negatif', atau 'netral'")
+	}
+
+	left = 0
+	right = nComment - 1
+
+	for left <= right {
+		mid = (left + right) / 2
+
+		if comments[mid].id == id {
+			comments[mid].kategoriKonfirmasi = kategori
+			return nil
+		}
+
+		if comments[mid].id < id {
+			left = mid + 1
+		} else {
+			right = mid - 1
+		}
+	}
+
+	return fmt.Errorf("komentar dengan ID %d tidak ditemukan", id)
+}
+
+// SetConfidenceThreshold changes the confidence below which unconfirmed comments need review.
+func SetConfidenceThreshold(ambang float64) error {
+	if ambang < 0 || ambang > 1 {
+		return fmt.Errorf("ambang keyakinan harus antara 0 dan 1")
+	}
+
+	ambangKeyakinan = ambang
+	return nil
+}
+
+// EditComment updates an existing comment's text with the provided value.
+// It searches for a comment with the specified ID in the global comments array.
+// When the text changes the comment is classified again and its confirmed category is cleared.
+func EditComment(komen string, id int) error {
+	var left, right, mid int
+	var result SentimentResult
+
+	left = 0
+	right = nComment - 1
+
+	for left <= right {
+		mid = (left + right) / 2
+
+		if comments[mid].id == id {
+			if komen != "" && komen != comments[mid].komentar {
+				AnalyzeSentiment(komen, &result)
+				UnindexComment(comments[mid])
+				comments[mid].komentar = komen
+				IndexComment(comments[mid])
+				updateShingles(&comments[mid])
+				comments[mid].kategori = result.kategori
+				comments[mid].keyakinan = result.keyakinan
+				comments[mid].kategoriKonfirmasi = ""
+				AnalyzeAspects(result, &comments[mid])
+				comments[mid].skorToksisitas = AnalyzeToxicity(komen)
+				comments[mid].toksik = comments[mid].skorToksisitas >= ambangToksisitas
+				holdToxicComment(&comments[mid], "otomatis: komentar diubah dan terdeteksi toksik")
+			}
+			return nil
+		}
+
+		if comments[mid].id < id {
+			left = mid + 1
+		} else {
+			right = mid - 1
+		}
+	}
+
+	return fmt.Errorf("komentar dengan ID %d tidak ditemukan", id)
+}
+
+// DeleteComment removes a comment with the specified ID from the comments array using binary search.
+// It assumes that the comments array is sorted by ID in ascending order.
+// Replies to the deleted comment are kept and moved up one level, so they become replies
+// to its parent, or top-level comments when it had none.
+// Once found, it deletes the comment by shifting all subsequent elements one
+// position to the left to fill the gap, then decrements the comment counter.
+func DeleteComment(id int) error {
+	var left, right, mid int
+
+	left = 0
+	right = nComment - 1
+
+	for left <= right {
+		mid = (left + right) / 2
+
+		if comments[mid].id == id {
+			for j := mid + 1; j < nComment; j++ {
+				if comments[j].parentId == id {
+					comments[j].parentId = comments[mid].parentId
+				}
+			}
+
+			UnindexComment(comments[mid])
+
+			for j := mid; j < nComment-1; j++ {
+				comments[j] = comments[j+1]
+			}
+			comments[nComment-1] = Comment{}
+			nComment--
+			clusterValid = false
+			return nil
+		}
+
+		if comments[mid].id < id {
+			left = mid + 1
+		} else {
+			right = mid - 1
+		}
+	}
+
+	return fmt.Errorf("komentar dengan ID %d tidak ditemukan", id)
+}
+
+// AnalyzeSentiment classifies a comment text using the keyword lexicon.
+// Each keyword adds its weight to the score, a preceding negation word flips the weight,
+// and a preceding intensifier multiplies it. The final score is compared against
+// ambangPositif and ambangNegatif to decide the category.
+func AnalyzeSentiment(komentar string, result *SentimentResult) {
+	var tokens [NTOKEN]string
+	var nToken int
+	var negated bool
+	var faktor float64 = 1
+	var nCocok int
+
+	tokenize(komentar, &tokens, &nToken)
+
+	result.skor = 0
+	result.nToken = nToken
+	for i := 0; i < nToken; i++ {
+		result.tokens[i] = TokenExplanation{token: tokens[i]}
+
+		if isNegationWord(tokens[i]) {
+			result.tokens[i].sumber = "negasi"
+			negated = !negated
+			continue
+		}
+
+		if f, ok := findIntensifier(tokens[i]); ok {
+			result.tokens[i].sumber = "intensifier"
+			result.tokens[i].bobot = f
+			faktor *= f
+			continue
+		}
+
+		bobot, ok := findLexiconWeight(tokens[i])
+		sumber := "leksikon"
+		if !ok {
+			bobot, ok = findEmojiWeight(tokens[i])
+			sumber = "emoji"
+		}
+
+		if ok {
+			kontribusi := bobot * faktor
+			if negated {
+				kontribusi = -kontribusi
+			}
+			result.tokens[i].sumber = sumber
+			result.tokens[i].bobot = bobot
+			result.tokens[i].faktor = faktor
+			result.tokens[i].negated = negated
+			result.tokens[i].kontribusi = kontribusi
+			result.skor += kontribusi
+			nCocok++
+		}
+
+		negated = false
+		faktor = 1
+	}
+
+	result.kategori = kategoriFromScore(result.skor)
+	result.keyakinan = confidenceFromScore(result.skor, nCocok)
+}
+
+// confidenceFromScore estimates how reliable a lexicon prediction is.
+// The confidence grows with the distance between the score and the nearest threshold that
+// would change the category, starting at 0.5 on the threshold itself. A comment without any
+// matching lexicon keyword is neutral and gets keyakinanTanpaKata instead.
+func confidenceFromScore(skor float64, nCocok int) float64 {
+	var jarak float64
+
+	if nCocok == 0 {
+		return keyakinanTanpaKata
+	}
+
+	if skor >= ambangPositif {
+		jarak = skor - ambangPositif
+	} else if skor <= ambangNegatif {
+		jarak = ambangNegatif - skor
+	} else {
+		jarak = math.Min(ambangPositif-skor, skor-ambangNegatif)
+	}
+
+	return 0.5 + 0.5*(1-math.Exp(-2*jarak))
+}
+
+// AnalyzeAspects computes the sentiment of a comment towards every defined aspect.
+// For each token that is a trigger keyword of an aspect, the contributions of the tokens within
+// aspekJendela positions on either side are added to the aspect score. Aspects that are not
+// mentioned in the comment are left out.
+func AnalyzeAspects(result SentimentResult, comment *Comment) {
+	comment.nAspek = 0
+
+	for a := 0; a < nAspect; a++ {
+		var skor float64
+		var counted [NTOKEN]bool
+		var mentioned bool
+
+		for i := 0; i < result.nToken; i++ {
+			if !isAspectKeyword(aspects[a], result.tokens[i].token) {
+				continue
+			}
+			mentioned = true
+
+			for j := i - aspekJendela; j <= i+aspekJendela; j++ {
+				if j >= 0 && j < result.nToken && !counted[j] {
+					skor += result.tokens[j].kontribusi
+					counted[j] = true
+				}
+			}
+		}
+
+		if mentioned {
+			comment.aspek[comment.nAspek] = AspectSentiment{
+				aspekId:  aspects[a].id,
+				kategori: kategoriFromScore(skor),
+				skor:     skor,
+			}
+			comment.nAspek++
+		}
+	}
+}
+
+// isAspectKeyword reports whether the token is one of the trigger keywords of the aspect.
+func isAspectKeyword(aspect Aspect, token string) bool {
+	for k := 0; k < aspect.nKata; k++ {
+		if aspect.kataKunci[k] == token {
+			return true
+		}
+	}
+	return false
+}
+
+// RecomputeAspectSentiments recalculates the aspect sentiment of every comment.
+// It is called whenever an aspect is added, changed, or removed.
+func RecomputeAspectSentiments() {
+	var result SentimentResult
+
+	for i := 0; i < nComment; i++ {
+		AnalyzeSentiment(comments[i].komentar, &result)
+		AnalyzeAspects(result, &comments[i])
+	}
+}
+
+// GetAspects retrieves all defined aspects and copies them to the provided array.
+func GetAspects(aspectsInput *[NASPEK]Aspect) error {
+	if nAspect == 0 {
+		return fmt.Errorf("tidak ada aspek yang terdefinisi")
+	}
+
+	*aspectsInput = aspects
+	return nil
+}
+
+// FindAspectById searches for an aspect with the specified ID using binary search.
+// It assumes that the aspects array is sorted by ID in ascending order.
+// If found, it copies the aspect data to the provided aspect pointer.
+func FindAspectById(id int, aspect *Aspect) error {
+	var left, right, mid int
+
+	left = 0
+	right = nAspect - 1
+
+	for left <= right {
+		mid = (left + right) / 2
+
+		if aspects[mid].id == id {
+			*aspect = aspects[mid]
+			return nil
+		}
+
+		if aspects[mid].id < id {
+			left = mid + 1
+		} else {
+			right = mid - 1
+		}
+	}
+
+	return fmt.Errorf("aspek dengan ID %d tidak ditemukan", id)
+}
+
+// CreateAspect adds a new aspect with the given name and trigger keywords, assigns it a
+// unique ID, and recomputes the aspect sentiment of all comments.
+func CreateAspect(nama string, kataKunci [NKATAASPEK]string, nKata int) error {
+	if nAspect >= NASPEK {
+		return fmt.Errorf("jumlah aspek sudah mencapai batas maksimum")
+	}
+
+	for i := 0; i < nAspect; i++ {
+		if aspects[i].nama == nama {
+			return fmt.Errorf("aspek '%s' sudah terdaftar", nama)
+		}
+	}
+
+	aspects[nAspect] = Aspect{
+		id:        idAspect,
+		nama:      nama,
+		kataKunci: kataKunci,
+		nKata:     nKata,
+	}
+	nAspect++
+	idAspect++
+
+	RecomputeAspectSentiments()
+	return nil
+}
+
+// EditAspect updates the name and/or trigger keywords of an aspect using binary search to find it,
+// then recomputes the aspect sentiment of all comments.
+// It assumes that the aspects array is sorted by ID in ascending order.
+func EditAspect(nama string, kataKunci [NKATAASPEK]string, nKata int, id int) error {
+	var left, right, mid int
+
+	left = 0
+	right = nAspect - 1
+
+	for left <= right {
+		mid = (left + right) / 2
+
+		if aspects[mid].id == id {
+			if nama != "" {
+				aspects[mid].nama = nama
+			}
+			if nKata > 0 {
+				aspects[mid].kataKunci = kataKunci
+				aspects[mid].nKata = nKata
+			}
+			RecomputeAspectSentiments()
+			return nil
+		}
+
+		if aspects[mid].id < id {
+			left = mid + 1
+		} else {
+			right = mid - 1
+		}
+	}
+
+	return fmt.Errorf("aspek dengan ID %d tidak ditemukan", id)
+}
+
+// DeleteAspect removes an aspect with the specified ID using binary search, shifting the
+// subsequent aspects one position to the left, then recomputes the aspect sentiment of all comments.
+// It assumes that the aspects array is sorted by ID in ascending order.
+func DeleteAspect(id int) error {
+	var left, right, mid int
+
+	left = 0
+	right = nAspect - 1
+
+	for left <= right {
+		mid = (left + right) / 2
+
+		if aspects[mid].id == id {
+			for j := mid; j < nAspect-1; j++ {
+				aspects[j] = aspects[j+1]
+			}
+			aspects[nAspect-1] = Aspect{}
+			nAspect--
+			RecomputeAspectSentiments()
+			return nil
+		}
+
+		if aspects[mid].id < id {
+			left = mid + 1
+		} else {
+			right = mid - 1
+		}
+	}
+
+	return fmt.Errorf("aspek dengan ID %d tidak ditemukan", id)
+}
+
+// CountAspectByCategory counts the comments from sumber whose sentiment towards the given aspect matches the category.
+func CountAspectByCategory(aspekId int, category string, sumber CommentSource) int {
+	var count int
+
+	for i := 0; i < nComment; i++ {
+		if !matchSource(comments[i], sumber) {
+			continue
+		}
+		for a := 0; a < comments[i].nAspek; a++ {
+			if comments[i].aspek[a].aspekId == aspekId && comments[i].aspek[a].kategori == category {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+// AnalyzeToxicity computes the toxicity score of a comment text as the sum of the severity of
+// every abusive word it contains. Words are compared after undoing leetspeak and collapsing
+// repeated letters, so "4nj1ng" and "anjiiing" are both recognized as "anjing".
+func AnalyzeToxicity(komentar string) float64 {
+	var starts, ends [NTOKEN]int
+	var n int
+	var skor float64
+
+	runes := []rune(toLower(komentar))
+	findToxicSpans(runes, &starts, &ends, &n)
+
+	for i := 0; i < n; i++ {
+		if tingkat, ok := findToxicWord(string(runes[starts[i]:ends[i]])); ok {
+			skor += tingkat
+		}
+	}
+
+	return skor
+}
+
+// MaskProfanity replaces every abusive word in the text with its first letter followed by asterisks,
+// leaving the rest of the text unchanged.
+func MaskProfanity(komentar string) string {
+	var starts, ends [NTOKEN]int
+	var n int
+
+	runes := []rune(komentar)
+	lower := []rune(toLower(komentar))
+	findToxicSpans(lower, &starts, &ends, &n)
+
+	for i := 0; i < n; i++ {
+		if _, ok := findToxicWord(string(lower[starts[i]:ends[i]])); ok {
+			for j := starts[i] + 1; j < ends[i]; j++ {
+				runes[j] = '*'
+			}
+		}
+	}
+
+	return string(runes)
+}
+
+// findToxicSpans records the start and end positions of the words in a lowercased text.
+// Unlike tokenize, the leetspeak symbols '@', '$', and '!' are kept inside words,
+// except for exclamation marks at the end of a word.
+func findToxicSpans(runes []rune, starts, ends *[NTOKEN]int, n *int) {
+	var start int = -1
+
+	*n = 0
+	for i := 0; i <= len(runes); i++ {
+		isWordChar := i < len(runes) && (isWordRune(runes[i]) || runes[i] == '@' || runes[i] == '$' || runes[i] == '!')
+
+		if isWordChar && start == -1 {
+			start = i
+		} else if !isWordChar && start != -1 {
+			end := i
+			for end > start && runes[end-1] == '!' {
+				end--
+			}
+
+			if end > start && *n < NTOKEN {
+				starts[*n] = start
+				ends[*n] = end
+				*n++
+			}
+			start = -1
+		}
+	}
+}
+
+// findToxicWord normalizes a word and searches the toxicWords list for it using sequential search.
+// It returns the severity of the word and whether it was found.
+func findToxicWord(word string) (float64, bool) {
+	normalized := normalizeLeet(word)
+
+	for i := 0; i < nToxicWord; i++ {
+		if normalizeLeet(toxicWords[i].kata) == normalized {
+			return toxicWords[i].tingkat, true
+		}
+	}
+	return 0, false
+}
+
+// normalizeLeet converts common leetspeak characters to the letters they stand for,
+// drops any remaining non-letter characters, and collapses runs of the same letter.
+func normalizeLeet(word string) string {
+	var result []rune
+
+	for _, r := range word {
+		switch r {
+		case '4', '@':
+			r = 'a'
+		case '1', '!':
+			r = 'i'
+		case '3':
+			r = 'e'
+		case '0':
+			r = 'o'
+		case '5', '$':
+			r = 's'
+		case '7':
+			r = 't'
+		case '9':
+			r = 'g'
+		}
+
+		if r < 'a' || r > 'z' {
+			continue
+		}
+
+		if len(result) > 0 && result[len(result)-1] == r {
+			continue
+		}
+		result = append(result, r)
+	}
+
+	return string(result)
+}
+
+// RecomputeToxicity recalculates the toxicity score and flag of every comment.
+// It is called whenever the abusive word list or the threshold changes. Approved comments that
+// become toxic are moved back to pending, the same way as when a comment is edited.
+func RecomputeToxicity() {
+	for i := 0; i < nComment; i++ {
+		wasToxic := comments[i].toksik
+		comments[i].skorToksisitas = AnalyzeToxicity(comments[i].komentar)
+		comments[i].toksik = comments[i].skorToksisitas >= ambangToksisitas
+		if !wasToxic {
+			holdToxicComment(&comments[i], "otomatis: terdeteksi toksik setelah aturan toksisitas diubah")
+		}
+	}
+}
+
+// holdToxicComment moves an approved comment that is flagged as toxic to pending with the given reason,
+// so it leaves the public listing until a moderator reviews it.
+func holdToxicComment(comment *Comment, alasan string) {
+	if comment.toksik && comment.status == "approved" {
+		comment.status = "pending"
+		comment.alasanStatus = alasan
+		comment.statusOleh = "sistem"
+		comment.statusDiubah = time.Now()
+	}
+}
+
+// CreateToxicWord adds an abusive word with the given severity to the toxicity detector.
+func CreateToxicWord(kata string, tingkat float64) error {
+	if nToxicWord >= NMAX {
+		return fmt.Errorf("jumlah kata kasar sudah mencapai batas maksimum")
+	}
+
+	if kata == "" || tingkat <= 0 {
+		return fmt.Errorf("kata tidak boleh kosong dan tingkat harus lebih dari 0")
+	}
+
+	kata = toLower(kata)
+	if _, ok := findToxicWord(kata); ok {
+		return fmt.Errorf("kata '%s' sudah terdaftar", kata)
+	}
+
+	toxicWords[nToxicWord] = ToxicWord{kata: kata, tingkat: tingkat}
+	nToxicWord++
+
+	RecomputeToxicity()
+	return nil
+}
+
+// DeleteToxicWord removes an abusive word from the toxicity detector using sequential search,
+// shifting the subsequent words one position to the left.
+func DeleteToxicWord(kata string) error {
+	kata = toLower(kata)
+
+	for i := 0; i < nToxicWord; i++ {
+		if toxicWords[i].kata == kata {
+			for j := i; j < nToxicWord-1; j++ {
+				toxicWords[j] = toxicWords[j+1]
+			}
+			toxicWords[nToxicWord-1] = ToxicWord{}
+			nToxicWord--
+
+			RecomputeToxicity()
+			return nil
+		}
+	}
+
+	return fmt.Errorf("kata '%s' tidak ditemukan", kata)
+}
+
+// SetToxicityThreshold changes the minimum toxicity score for a comment to be flagged.
+func SetToxicityThreshold(ambang float64) error {
+	if ambang <= 0 {
+		return fmt.Errorf("ambang toksisitas harus lebih dari 0")
+	}
+
+	ambangToksisitas = ambang
+	RecomputeToxicity()
+	return nil
+}
+
+// GetCommentsToxic collects the comments flagged as toxic into the provided array.
+func GetCommentsToxic(commentsInput *[NMAX]Comment) error {
+	var matchCount int
+
+	if nComment == 0 {
+		return fmt.Errorf("tidak ada komentar yang tersedia")
+	}
+
+	var tempComments [NMAX]Comment
+	for i := 0; i < nComment; i++ {
+		if comments[i].toksik {
+			tempComments[matchCount] = comments[i]
+			matchCount++
+		}
+	}
+
+	if matchCount == 0 {
+		return fmt.Errorf("tidak ada komentar yang ditandai toksik")
+	}
+
+	*commentsInput = tempComments
+	return nil
+}
+
+// CountToxicComments counts the number of comments from sumber flagged as toxic.
+func CountToxicComments(sumber CommentSource) int {
+	var count int
+
+	for i := 0; i < nComment; i++ {
+		if comments[i].toksik && matchSource(comments[i], sumber) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// kategoriFromScore maps a lexicon score to a sentiment category using the thresholds.
+func kategoriFromScore(skor float64) string {
+	if skor >= ambangPositif {
+		return "positif"
+	} else if skor <= ambangNegatif {
+		return "negatif"
+	}
+	return "netral"
+}
+
+// findLexiconWeight searches the lexicon for the given token using sequential search.
+// It returns the keyword weight and whether the token was found.
+func findLexiconWeight(token string) (float64, bool) {
+	for i := 0; i < nLexicon; i++ {
+		if lexicon[i].kata == token {
+			return lexicon[i].bobot, true
+		}
+	}
+	return 0, false
+}
+
+// findEmojiWeight searches the emoji and emoticon table for the given token using sequential search.
+// It returns the sentiment weight and whether the token was found.
+func findEmojiWeight(token string) (float64, bool) {
+	for i := 0; i < nEmoji; i++ {
+		if emojiLexicon[i].kata == token {
+			return emojiLexicon[i].bobot, true
+		}
+	}
+	return 0, false
+}
+
+// findIntensifier searches the intensifier list for the given token using sequential search.
+// It returns the multiplier and whether the token was found.
+func findIntensifier(token string) (float64, bool) {
+	for i := 0; i < nIntensifier; i++ {
+		if intensifierWords[i].kata == token {
+			return intensifierWords[i].faktor, true
+		}
+	}
+	return 0, false
+}
+
+// isNegationWord reports whether the token is one of the negation words.
+func isNegationWord(token string) bool {
+	for i := 0; i < nNegation; i++ {
+		if negationWords[i] == token {
+			return true
+		}
+	}
+	return false
+}
+
+// TrainNaiveBayes trains a Naive Bayes model from the comments at the given indices.
+// Only comments with a category confirmed by a moderator are used for training.
+func TrainNaiveBayes(model *NaiveBayesModel, indices [NMAX]int, n int) {
+	var tokens [NTOKEN]string
+	var nToken int
+
+	*model = NaiveBayesModel{}
+
+	for i := 0; i < n; i++ {
+		c := comments[indices[i]]
+		k := kategoriIndex(c.kategoriKonfirmasi)
+		if k == -1 {
+			continue
+		}
+
+		model.docCount[k]++
+		tokenize(c.komentar, &tokens, &nToken)
+
+		for t := 0; t < nToken; t++ {
+			v := -1
+			for j := 0; j < model.nVocab; j++ {
+				if model.vocab[j] == tokens[t] {
+					v = j
+					break
+				}
+			}
+
+			if v == -1 {
+				if model.nVocab >= NVOCAB {
+					continue
+				}
+				v = model.nVocab
+				model.vocab[v] = tokens[t]
+				model.nVocab++
+			}
+
+			model.counts[v][k]++
+			model.tokenTotal[k]++
+		}
+	}
+}
+
+// PredictNaiveBayes returns the most probable category for a comment text.
+// Log probabilities with Laplace smoothing are used so that unseen words do not zero out a category.
+func PredictNaiveBayes(model *NaiveBayesModel, komentar string) string {
+	var tokens [NTOKEN]string
+	var nToken int
+	var logProb [3]float64
+	var totalDoc int
+
+	tokenize(komentar, &tokens, &nToken)
+
+	for k := 0; k < 3; k++ {
+		totalDoc += model.docCount[k]
+	}
+
+	for k := 0; k < 3; k++ {
+		logProb[k] = math.Log(float64(model.docCount[k]+1) / float64(totalDoc+3))
+
+		for t := 0; t < nToken; t++ {
+			logProb[k] += NaiveBayesWordLogProb(model, tokens[t], k)
+		}
+	}
+
+	best := 0
+	for k := 1; k < 3; k++ {
+		if logProb[k] > logProb[best] {
+			best = k
+		}
+	}
+
+	return kategoriList[best]
+}
+
+// NaiveBayesWordLogProb returns the smoothed log probability of a word given the category
+// at index k. It is the per-word feature weight used by PredictNaiveBayes.
+func NaiveBayesWordLogProb(model *NaiveBayesModel, word string, k int) float64 {
+	var count int
+
+	for j := 0; j < model.nVocab; j++ {
+		if model.vocab[j] == word {
+			count = model.counts[j][k]
+			break
+		}
+	}
+
+	return math.Log(float64(count+1) / float64(model.tokenTotal[k]+model.nVocab+1))
+}
+
+// TrainNaiveBayesAll trains a Naive Bayes model using every labeled comment in the system.
+func TrainNaiveBayesAll(model *NaiveBayesModel) {
+	var indices [NMAX]int
+
+	for i := 0; i < nComment; i++ {
+		indices[i] = i
+	}
+
+	TrainNaiveBayes(model, indices, nComment)
+}
+
+// EvaluateLexicon compares the lexicon analyzer predictions against the categories confirmed by moderators.
+// Comments without a confirmed category are skipped.
+func EvaluateLexicon(report *EvaluationReport) error {
+	var result SentimentResult
+
+	*report = EvaluationReport{model: "lexicon"}
+
+	for i := 0; i < nComment; i++ {
+		actual := kategoriIndex(comments[i].kategoriKonfirmasi)
+		if actual == -1 {
+			continue
+		}
+
+		AnalyzeSentiment(comments[i].komentar, &result)
+		report.matrix[actual][kategoriIndex(result.kategori)]++
+	}
+
+	return finalizeReport(report)
+}
+
+// CrossValidateNaiveBayes evaluates the Naive Bayes model using k-fold cross-validation.
+// Comments with a confirmed category are assigned to folds in round-robin order; each fold is predicted by a model
+// trained on the remaining folds and the predictions are accumulated into one confusion matrix.
+func CrossValidateNaiveBayes(k int, report *EvaluationReport) error {
+	var labeled [NMAX]int
+	var nLabeled int
+	var model NaiveBayesModel
+
+	if k < 2 || k > 10 {
+		return fmt.Errorf("jumlah fold harus antara 2 dan 10")
+	}
+
+	for i := 0; i < nComment; i++ {
+		if kategoriIndex(comments[i].kategoriKonfirmasi) != -1 {
+			labeled[nLabeled] = i
+			nLabeled++
+		}
+	}
+
+	if nLabeled < k {
+		return fmt.Errorf("jumlah komentar berlabel (%d) kurang dari jumlah fold", nLabeled)
+	}
+
+	*report = EvaluationReport{model: "naive-bayes", folds: k}
+
+	for fold := 0; fold < k; fold++ {
+		var train [NMAX]int
+		var nTrain, correct, tested int
+
+		for i := 0; i < nLabeled; i++ {
+			if i%k != fold {
+				train[nTrain] = labeled[i]
+				nTrain++
+			}
+		}
+
+		TrainNaiveBayes(&model, train, nTrain)
+
+		for i := fold; i < nLabeled; i += k {
+			c := comments[labeled[i]]
+			actual := kategoriIndex(c.kategoriKonfirmasi)
+			predicted := kategoriIndex(PredictNaiveBayes(&model, c.komentar))
+
+			report.matrix[actual][predicted]++
+			tested++
+			if actual == predicted {
+				correct++
+			}
+		}
+
+		report.foldAccuracy[fold] = float64(correct) / float64(tested)
+	}
+
+	return finalizeReport(report)
+}
+
+// finalizeReport computes the totals, per-category metrics, and macro averages
+// from the confusion matrix of the report.
+func finalizeReport(report *EvaluationReport) error {
+	var correct int
+
+	report.total = 0
+	for a := 0; a < 3; a++ {
+		for p := 0; p < 3; p++ {
+			report.total += report.matrix[a][p]
+		}
+		correct += report.matrix[a][a]
+	}
+
+	if report.total == 0 {
+		return fmt.Errorf("tidak ada komentar berlabel untuk dievaluasi")
+	}
+
+	report.accuracy = float64(correct) / float64(report.total)
+	report.macroPrecision, report.macroRecall, report.macroF1 = 0, 0, 0
+
+	for k := 0; k < 3; k++ {
+		var predictedTotal, actualTotal int
+
+		for j := 0; j < 3; j++ {
+			predictedTotal += report.matrix[j][k]
+			actualTotal += report.matrix[k][j]
+		}
+
+		report.precision[k] = safeDivide(float64(report.matrix[k][k]), float64(predictedTotal))
+		report.recall[k] = safeDivide(float64(report.matrix[k][k]), float64(actualTotal))
+		report.f1[k] = safeDivide(2*report.precision[k]*report.recall[k], report.precision[k]+report.recall[k])
+
+		report.macroPrecision += report.precision[k] / 3
+		report.macroRecall += report.recall[k] / 3
+		report.macroF1 += report.f1[k] / 3
+	}
+
+	return nil
+}
+
+// ExportEvaluationJSON writes the first n evaluation reports to the given path as a JSON array.
+// Reports with no evaluated comments are skipped.
+func ExportEvaluationJSON(path string, reports [2]EvaluationReport, n int) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("gagal membuat file: %s", err.Error())
+	}
+	defer file.Close()
+
+	fmt.Fprint(file, "[")
+	var written int
+	for i := 0; i < n; i++ {
+		r := reports[i]
+		if r.total == 0 {
+			continue
+		}
+
+		if written > 0 {
+			fmt.Fprint(file, ",")
+		}
+		written++
+
+		fmt.Fprintf(file, "\n  {\n    \"model\": %q,\n    \"total\": %d,\n    \"accuracy\": %.4f,\n", r.model, r.total, r.accuracy)
+		fmt.Fprint(file, "    \"labels\": [\"positif\", \"netral\", \"negatif\"],\n    \"confusion_matrix\": [")
+		for a := 0; a < 3; a++ {
+			if a > 0 {
+				fmt.Fprint(file, ", ")
+			}
+			fmt.Fprintf(file, "[%d, %d, %d]", r.matrix[a][0], r.matrix[a][1], r.matrix[a][2])
+		}
+		fmt.Fprint(file, "],\n    \"per_class\": {")
+		for k := 0; k < 3; k++ {
+			if k > 0 {
+				fmt.Fprint(file, ",")
+			}
+			fmt.Fprintf(file, "\n      %q: {\"precision\": %.4f, \"recall\": %.4f, \"f1\": %.4f}", kategoriList[k], r.precision[k], r.recall[k], r.f1[k])
+		}
+		fmt.Fprintf(file, "\n    },\n    \"macro\": {\"precision\": %.4f, \"recall\": %.4f, \"f1\": %.4f}", r.macroPrecision, r.macroRecall, r.macroF1)
+		if r.folds > 0 {
+			fmt.Fprintf(file, ",\n    \"folds\": %d,\n    \"fold_accuracy\": [", r.folds)
+			for f := 0; f < r.folds; f++ {
+				if f > 0 {
+					fmt.Fprint(file, ", ")
+				}
+				fmt.Fprintf(file, "%.4f", r.foldAccuracy[f])
+			}
+			fmt.Fprint(file, "]")
+		}
+		fmt.Fprint(file, "\n  }")
 	}
+	fmt.Fprintln(file, "\n]")
 
 	return nil
 }
 
-// ConfirmForm prompts the user with a yes/no question and returns the result.
-// It displays the provided title followed by options for Yes (1) or No (2),
-// then reads the user's selection from standard input.
-func ConfirmForm(title string) error {
-	var input int
+// BenchmarkCommand runs the benchmark from the command line: benchmark [-ulangan N] [-csv file] [size ...].
+// The table is printed to standard output and the CSV is written when a file is given.
+// It runs without a session because it only touches the synthetic comments it generates.
+func BenchmarkCommand(args []string) error {
+	var ukuran [NBENCHSIZE]int
+	var nUkuran, nHasil int
+	var ulangan int = ulanganBenchmarkDefault
+	var path string
+	var hasil [NBENCH]BenchmarkResult
+
+	for i := 0; i < len(args); i++ {
+		if (args[i] == "-ulangan" || args[i] == "-csv") && i+1 >= len(args) {
+			return fmt.Errorf("nilai untuk %s belum diisi", args[i])
+		}
+
+		switch args[i] {
+		case "-ulangan":
+			nilai, ok := parseNumber(args[i+1])
+			if !ok {
+				return fmt.Errorf("jumlah pengulangan '%s' bukan angka", args[i+1])
+			}
+			ulangan = nilai
+			i++
+		case "-csv":
+			path = args[i+1]
+			i++
+		default:
+			nilai, ok := parseNumber(args[i])
+			if !ok {
+				return fmt.Errorf("argumen '%s' tidak dikenal, gunakan: benchmark [-ulangan N] [-csv file] [ukuran ...]", args[i])
+			}
+			if nUkuran >= NBENCHSIZE {
+				return fmt.Errorf("maksimal %d ukuran data", NBENCHSIZE)
+			}
+			ukuran[nUkuran] = nilai
+			nUkuran++
+		}
+	}
+
+	if nUkuran == 0 {
+		ukuran = [NBENCHSIZE]int{50, 100, NMAX}
+		nUkuran = 3
+	}
+
+	if err := RunBenchmark(ukuran, nUkuran, ulangan, &hasil, &nHasil); err != nil {
+		return err
+	}
+	PrintBenchmarkTable(hasil, nHasil)
+
+	if path != "" {
+		if err := ExportBenchmarkCSV(path, hasil, nHasil); err != nil {
+			return err
+		}
+		fmt.Println("Hasil berhasil diekspor ke", path)
+	}
+
+	return nil
+}
+
+// RunBenchmark generates a synthetic comment set for every size and measures sequential search with
+// GetCommentsSearch, binary search with FindCommentById, and selection and insertion sort with
+// GetCommentsSort on it. The stored comments are swapped out while the benchmark runs and restored afterwards.
+func RunBenchmark(ukuran [NBENCHSIZE]int, nUkuran, ulangan int, hasil *[NBENCH]BenchmarkResult, nHasil *int) error {
+	if nUkuran == 0 {
+		return fmt.Errorf("ukuran data belum diisi")
+	}
+	for i := 0; i < nUkuran; i++ {
+		if ukuran[i] < 1 || ukuran[i] > NMAX {
+			return fmt.Errorf("ukuran data harus antara 1 dan %d", NMAX)
+		}
+	}
+	if ulangan < 1 {
+		return fmt.Errorf("jumlah pengulangan harus lebih dari 0")
+	}
+	if nUkuran*4 > NBENCH {
+		return fmt.Errorf("terlalu banyak ukuran data, maksimal %d", NBENCH/4)
+	}
+
+	simpanKomentar, simpanN, simpanId := comments, nComment, idComment
+	defer func() {
+		comments, nComment, idComment = simpanKomentar, simpanN, simpanId
+	}()
+
+	*nHasil = 0
+	for u := 0; u < nUkuran; u++ {
+		var seed uint32 = uint32(ukuran[u])
+
+		GenerateSyntheticComments(ukuran[u], &seed)
+
+		search := BenchmarkResult{algoritma: "Sequential Search", fungsi: "GetCommentsSearch", ukuran: ukuran[u], ulangan: ulangan}
+		binary := BenchmarkResult{algoritma: "Binary Search", fungsi: "FindCommentById", ukuran: ukuran[u], ulangan: ulangan}
+		for r := 0; r < ulangan; r++ {
+			var hasilCari [NMAX]Comment
+			var comment Comment
+
+			kata := kataSintetis[nextRandom(&seed)%len(kataSintetis)]
+			mulai := time.Now()
+			GetCommentsSearch(&hasilCari, kata)
+			search.durasi += time.Since(mulai)
+			search.perbandingan += nComment
+
+			id := nextRandom(&seed)%nComment + 1
+			mulai = time.Now()
+			FindCommentById(id, &comment)
+			binary.durasi += time.Since(mulai)
+			findCommentIndex(id, &binary.perbandingan)
+		}
+		hasil[*nHasil] = search
+		hasil[*nHasil+1] = binary
+		*nHasil += 2
+
+		for a := 0; a < len(algoritmaSort); a++ {
+			var stats SortStats
+
+			spec := SortSpec{kunci: [NSORTKEY]string{"panjang"}, nKunci: 1, algoritma: algoritmaSort[a]}
+			sort := BenchmarkResult{algoritma: "Selection Sort", fungsi: "GetCommentsSort", ukuran: ukuran[u], ulangan: ulangan}
+			if algoritmaSort[a] == "insertion" {
+				sort.algoritma = "Insertion Sort"
+			}
+
+			for r := 0; r < ulangan; r++ {
+				data := comments
+
+				mulai := time.Now()
+				if err := GetCommentsSort(&data, spec, &stats); err != nil {
+					return err
+				}
+				sort.durasi += time.Since(mulai)
+				sort.perbandingan += stats.perbandingan
+				sort.pertukaran += stats.pertukaran
+			}
+
+			hasil[*nHasil] = sort
+			*nHasil++
+		}
+	}
+
+	return nil
+}
+
+// GenerateSyntheticComments replaces the stored comments with n comments of 3 to 12 random words
+// from kataSintetis and random categories. The same seed always gives the same comments.
+func GenerateSyntheticComments(n int, seed *uint32) {
+	comments = [NMAX]Comment{}
+	for i := 0; i < n; i++ {
+		var komentar string
+
+		nKata := 3 + nextRandom(seed)%10
+		for k := 0; k < nKata; k++ {
+			if k > 0 {
+				komentar += " "
+			}
+			komentar += kataSintetis[nextRandom(seed)%len(kataSintetis)]
+		}
+
+		comments[i] = Comment{
+			id:       i + 1,
+			userId:   1 + nextRandom(seed)%10,
+			komentar: komentar,
+			kategori: kategoriList[nextRandom(seed)%len(kategoriList)],
+			dibuat:   time.Now(),
+			status:   "approved",
+		}
+		updateShingles(&comments[i])
+	}
+	nComment = n
+	idComment = n + 1
+}
+
+// PrintBenchmarkTable prints the benchmark results as a table with the average time and operation
+// counts per repetition.
+func PrintBenchmarkTable(hasil [NBENCH]BenchmarkResult, n int) {
+	fmt.Printf("%-18s %-18s %7s %8s %14s %14s %12s\n", "Algoritma", "Fungsi", "Ukuran", "Ulangan", "Rata-rata", "Perbandingan", "Pertukaran")
+	for i := 0; i < n; i++ {
+		r := hasil[i]
+		fmt.Printf("%-18s %-18s %7d %8d %14s %14.1f %12.1f\n", r.algoritma, r.fungsi, r.ukuran, r.ulangan, r.durasi/time.Duration(r.ulangan), float64(r.perbandingan)/float64(r.ulangan), float64(r.pertukaran)/float64(r.ulangan))
+	}
+}
+
+// ExportBenchmarkCSV writes the benchmark results to a CSV file with one row per algorithm and size.
+// Times are in nanoseconds and the operation counts are averages per repetition.
+func ExportBenchmarkCSV(path string, hasil [NBENCH]BenchmarkResult, n int) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("gagal membuat file: %s", err.Error())
+	}
+	defer file.Close()
+
+	fmt.Fprintln(file, "algoritma,fungsi,ukuran,ulangan,total_ns,rata_rata_ns,perbandingan,pertukaran")
+	for i := 0; i < n; i++ {
+		r := hasil[i]
+		fmt.Fprintf(file, "%s,%s,%d,%d,%d,%d,%.1f,%.1f\n", r.algoritma, r.fungsi, r.ukuran, r.ulangan, r.durasi.Nanoseconds(), r.durasi.Nanoseconds()/int64(r.ulangan), float64(r.perbandingan)/float64(r.ulangan), float64(r.pertukaran)/float64(r.ulangan))
+	}
+
+	return nil
+}
+
+// PrintTitle formats and displays the given title text within a bordered box.
+// If the title is longer than the predefined width (38 characters), it will be
+// split into multiple lines, breaking at spaces when possible.
+//
+// The function centers each line of text and adds decorative borders
+// around the entire title.
+func PrintTitle(title string) {
+	const width int = 38
+	var start, currentPos, lastSpace int
+
+	if len(title) <= width {
+		printBorder()
+		printCenteredText(title, width)
+		printBorder()
+		return
+	}
+
+	printBorder()
+
+	start = 0
+	currentPos = 0
+	lastSpace = -1
+
+	for currentPos < len(title) {
+		if currentPos-start >= width {
+			if lastSpace > start {
+				printCenteredText(title[start:lastSpace], width)
+				start = lastSpace + 1
+				currentPos = start
+				lastSpace = -1
+			} else {
+				printCenteredText(title[start:currentPos], width)
+				start = currentPos
+			}
+		} else if title[currentPos] == ' ' {
+			lastSpace = currentPos
+			currentPos++
+		} else {
+			currentPos++
+		}
+	}
+
+	if start < len(title) {
+		printCenteredText(title[start:], width)
+	}
+
+	printBorder()
+}
+
+// printBorder prints a horizontal border consisting of 42 equal signs.
+// Used to create the top and bottom borders of the title box.
+func printBorder() {
+	fmt.Println("==========================================")
+}
+
+// printCenteredText formats and prints a single line of text, centered within
+// the specified width. The text is surrounded by "= " on the left and " =" on the right.
+//
+// For odd-length text, an extra space is added to the right padding to maintain
+// proper centering and border alignment.
+func printCenteredText(text string, width int) {
+	var leftPadding, rightPadding int
+
+	leftPadding = (width - len(text)) / 2
+	rightPadding = (width - len(text)) / 2
+
+	if len(text)%2 != 0 {
+		rightPadding++
+	}
+
+	fmt.Print("= ")
+	for i := 0; i < leftPadding; i++ {
+		fmt.Print(" ")
+	}
+	fmt.Print(text)
+	for i := 0; i < rightPadding; i++ {
+		fmt.Print(" ")
+	}
+	fmt.Println(" =")
+}
+
+// PrintMenu displays a menu of options and captures the user's selection.
+// The function first displays all menu options as a numbered list. It then enters a loop
+// that prompts the user for input until a valid selection is made. If the user enters
+// an invalid option (outside the range 1-n), an error message is displayed and the user
+// is prompted again. This continues until a valid selection is made or an input error occurs.
+func PrintMenu(menuTitle string, menu [255]string, n int, answer *int) error {
+	for i := 0; i < n; i++ {
+		fmt.Printf("%d. %s\n", i+1, menu[i])
+	}
 
 	for {
-		fmt.Printf("%s (1. Ya, 2. Tidak): ", title)
+		var input int
+		fmt.Printf("%s (1-%d): ", menuTitle, n)
 		_, err := fmt.Scan(&input)
+
 		if err != nil {
-			return err
+			fmt.Print(err.Error())
+			continue
 		}
 
-		if input == 1 {
+		if input >= 1 && input <= n {
+			*answer = input
 			return nil
-		} else if input == 2 {
-			return fmt.Errorf("cancel")
+		}
+
+		fmt.Printf("Pilihan tidak valid, silakan pilih antara 1 dan %d\n", n)
+	}
+}
+
+// PrintBreadcrumbs displays a hierarchical navigation path starting with "Main Menu".
+// It prints the first n elements from the links array, separated by " > " characters.
+// The last element is printed without a trailing separator.
+func PrintBreadcrumbs(links [255]string, n int) {
+	fmt.Print("Main Menu > ")
+	for i := 0; i < n; i++ {
+		if i == n-1 {
+			fmt.Print(links[i])
 		} else {
-			fmt.Println("Pilihan tidak valid, silakan pilih 1 atau 2.")
+			fmt.Print(links[i] + " > ")
+		}
+	}
+	fmt.Println()
+}
+
+// toLower converts a string to lowercase by changing any uppercase ASCII characters
+// (A-Z) and uppercase Latin-1 letters (À-Þ) to their lowercase equivalents.
+// The string is processed per rune so multi-byte characters such as emoji are left intact.
+func toLower(s string) string {
+	runes := []rune(s)
+
+	for i := 0; i < len(runes); i++ {
+		if (runes[i] >= 'A' && runes[i] <= 'Z') || (runes[i] >= 0xC0 && runes[i] <= 0xDE && runes[i] != 0xD7) {
+			runes[i] += 32
+		}
+	}
+
+	return string(runes)
+}
+
+// tokenize splits a text into lowercase tokens. The text is processed per rune so that
+// multi-byte characters are never split. Words are runs of letters and digits, every emoji
+// becomes its own token, and the emoticons from emojiLexicon are kept whole.
+// Any other character is a separator. At most NTOKEN tokens are stored.
+func tokenize(text string, tokens *[NTOKEN]string, n *int) {
+	var start int = -1
+
+	runes := []rune(normalizeEmoji(toLower(text)))
+	*n = 0
+
+	for i := 0; i <= len(runes); i++ {
+		isWordChar := i < len(runes) && isWordRune(runes[i])
+
+		if isWordChar && start == -1 {
+			start = i
+			continue
+		} else if isWordChar {
+			continue
+		}
+
+		if start != -1 {
+			addToken(tokens, n, string(runes[start:i]))
+			start = -1
+		}
+
+		if i == len(runes) {
+			break
+		}
+
+		if length := matchEmoticon(runes, i); length > 0 {
+			addToken(tokens, n, string(runes[i:i+length]))
+			i += length - 1
+		} else if isEmojiRune(runes[i]) {
+			addToken(tokens, n, string(runes[i]))
+		}
+	}
+}
+
+// splitWords splits a text on the separator into at most NKATAASPEK non-empty words.
+func splitWords(text string, separator byte, words *[NKATAASPEK]string, n *int) {
+	var start int
+
+	*n = 0
+	for i := 0; i <= len(text); i++ {
+		if i == len(text) || text[i] == separator {
+			if i > start && *n < NKATAASPEK {
+				words[*n] = text[start:i]
+				*n++
+			}
+			start = i + 1
+		}
+	}
+}
+
+// joinWords joins the first n words with a comma and a space.
+func joinWords(words [NKATAASPEK]string, n int) string {
+	var result string
+
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			result += ", "
+		}
+		result += words[i]
+	}
+
+	return result
+}
+
+// normalizeText prepares a comment text for duplicate detection: it is lowercased, emoji
+// modifiers are removed, and every run of characters that are not letters, digits, or emoji
+// is replaced by a single space.
+func normalizeText(text string) string {
+	var result []rune
+	var pendingSpace bool
+
+	for _, r := range normalizeEmoji(toLower(text)) {
+		if !isWordRune(r) && !isEmojiRune(r) {
+			pendingSpace = len(result) > 0
+			continue
+		}
+
+		if pendingSpace {
+			result = append(result, ' ')
+			pendingSpace = false
+		}
+		result = append(result, r)
+	}
+
+	return string(result)
+}
+
+// buildShingles stores the distinct shingles of panjangShingle runes found in a normalized text
+// in ascending order. Each shingle is placed with binary search, so duplicates are skipped on the way.
+// A text shorter than one shingle is used as a single shingle.
+func buildShingles(text string, shingles *[NSHINGLE]string, n *int) {
+	runes := []rune(text)
+	*n = 0
+
+	if len(runes) < panjangShingle {
+		if len(runes) > 0 {
+			shingles[0] = text
+			*n = 1
+		}
+		return
+	}
+
+	for i := 0; i+panjangShingle <= len(runes) && *n < NSHINGLE; i++ {
+		var left, right, mid int
+
+		shingle := string(runes[i : i+panjangShingle])
+
+		left = 0
+		right = *n
+		for left < right {
+			mid = (left + right) / 2
+			if shingles[mid] < shingle {
+				left = mid + 1
+			} else {
+				right = mid
+			}
 		}
-	}
-}
 
-// Data
+		if left < *n && shingles[left] == shingle {
+			continue
+		}
 
-// GetUsers retrieves all registered users from the system and copies them to the provided array.
-func GetUsers(usersInput *[NMAX]User) error {
-	if nUser == 0 {
-		return fmt.Errorf("tidak ada pengguna yang terdaftar")
+		for j := *n; j > left; j-- {
+			shingles[j] = shingles[j-1]
+		}
+		shingles[left] = shingle
+		*n++
 	}
+}
 
-	*usersInput = users
+// updateShingles stores the sorted shingles of the comment text on the comment, so duplicate
+// checks do not build them again, and marks the cached duplicate clusters as outdated.
+func updateShingles(comment *Comment) {
+	var shingles [NSHINGLE]string
+	var n int
 
-	return nil
+	buildShingles(normalizeText(comment.komentar), &shingles, &n)
+	comment.shingles = make([]string, n)
+	copy(comment.shingles, shingles[:n])
+	clusterValid = false
 }
 
-// GetUsersSearch searches for users whose usernames contain the specified substring.
-// It performs a case-insensitive search by converting both the search term and
-// usernames to lowercase before comparison.
-func GetUsersSearch(usersInput *[NMAX]User, search string) error {
-	var matchCount int
-	var isMatch bool
-
-	if nUser == 0 {
-		return fmt.Errorf("tidak ada pengguna yang terdaftar")
+// jaccardSimilarity returns the size of the intersection divided by the size of the union
+// of two shingle sets. Both sets must be sorted in ascending order; the intersection is counted
+// by walking them side by side.
+func jaccardSimilarity(a, b []string) float64 {
+	var i, j, common int
+
+	for i < len(a) && j < len(b) {
+		if a[i] == b[j] {
+			common++
+			i++
+			j++
+		} else if a[i] < b[j] {
+			i++
+		} else {
+			j++
+		}
 	}
 
-	var tempUsers [NMAX]User
-	matchCount = 0
+	return safeDivide(float64(common), float64(len(a)+len(b)-common))
+}
 
-	search = toLower(search)
+// addToken appends a token to the tokens array if there is still room.
+func addToken(tokens *[NTOKEN]string, n *int, token string) {
+	if *n < NTOKEN {
+		tokens[*n] = token
+		*n++
+	}
+}
 
-	for i := 0; i < nUser; i++ {
-		userLower := toLower(users[i].username)
-		isMatch = false
+// matchEmoticon returns the length in runes of the longest emoticon from emojiLexicon
+// that starts at position i, or 0 if none matches. Emoticons that begin with a word
+// character are ignored so that they cannot cut a word in half. An emoticon may follow a word
+// directly, as in "mantap:)", but not a digit, so times such as "10:30" do not match, and it may
+// not be followed by a word character or a slash, so the ":/" in a link is not an emoticon.
+func matchEmoticon(runes []rune, i int) int {
+	var best int
 
-		for j := 0; j <= len(userLower)-len(search); j++ {
-			isMatch = true
+	if i > 0 && runes[i-1] >= '0' && runes[i-1] <= '9' {
+		return 0
+	}
 
-			for k := 0; k < len(search); k++ {
-				if userLower[j+k] != search[k] {
-					isMatch = false
-					break
-				}
-			}
+	for e := 0; e < nEmoji; e++ {
+		emoticon := []rune(emojiLexicon[e].kata)
+		end := i + len(emoticon)
+		if isEmojiRune(emoticon[0]) || isWordRune(emoticon[0]) || len(emoticon) <= best || end > len(runes) {
+			continue
+		}
+		if end < len(runes) && (isWordRune(runes[end]) || runes[end] == '/') {
+			continue
+		}
 
-			if isMatch {
-				tempUsers[matchCount] = users[i]
-				matchCount++
+		isMatch := true
+		for k := 0; k < len(emoticon); k++ {
+			if runes[i+k] != emoticon[k] {
+				isMatch = false
 				break
 			}
 		}
-	}
-
-	if matchCount == 0 {
-		return fmt.Errorf("tidak ada username yang sesuai dengan pencarian")
-	}
 
-	for i := 0; i < NMAX; i++ {
-		if i < matchCount {
-			usersInput[i] = tempUsers[i]
-		} else {
-			usersInput[i] = User{}
+		if isMatch {
+			best = len(emoticon)
 		}
 	}
 
-	return nil
+	return best
 }
 
-// GetUsersSort sorts the users array by ID and stores the result in the provided usersInput.
-// It prompts the user to choose between ascending or descending sort order through a menu interface.
-// Selection sort is used for ascending order, and insertion sort is used for descending order.
-func GetUsersSort(usersInput *[NMAX]User) error {
-	var input int
-	var key User
+// highlightRange returns the characters of runes between mulai and akhir with the spans inside
+// that range marked by ANSI colour codes, or by brackets when warnaAktif is false.
+func highlightRange(runes []rune, mulai, akhir int, spans *[NTOKEN][2]int, n int) string {
+	var hasil string
 
-	if nUser == 0 {
-		return fmt.Errorf("tidak ada user yang tersedia")
+	tandaAwal, tandaAkhir := "[", "]"
+	if warnaAktif {
+		tandaAwal, tandaAkhir = "\033[1;33m", "\033[0m"
 	}
 
-	err := PrintMenu("Pilih Urutan", [255]string{"Ascending (A-Z)", "Descending (Z-A)"}, 2, &input)
-	if err != nil {
-		return err
-	}
+	pos := mulai
+	for i := 0; i < n; i++ {
+		if spans[i][1] <= mulai || spans[i][0] >= akhir {
+			continue
+		}
 
-	*usersInput = users
+		s, e := spans[i][0], spans[i][1]
+		if s < mulai {
+			s = mulai
+		}
+		if e > akhir {
+			e = akhir
+		}
 
-	if input == 1 {
-		for i := 0; i < nUser-1; i++ {
-			minIdx := i
-			for j := i + 1; j < nUser; j++ {
-				if usersInput[j].id < usersInput[minIdx].id {
-					minIdx = j
-				}
-			}
+		hasil += string(runes[pos:s]) + tandaAwal + string(runes[s:e]) + tandaAkhir
+		pos = e
+	}
 
-			usersInput[i], usersInput[minIdx] = usersInput[minIdx], usersInput[i]
-		}
-	} else {
-		for i := 1; i < nUser; i++ {
-			key = usersInput[i]
-			j := i - 1
+	return hasil + string(runes[pos:akhir])
+}
 
-			for j >= 0 && usersInput[j].id < key.id {
-				usersInput[j+1] = usersInput[j]
-				j--
-			}
+// matchCountLabel returns the number of matches shown next to a search result,
+// or an empty string when no search is active.
+func matchCountLabel(pencarian *SearchMatcher, n int) string {
+	if pencarian == nil || n == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%d kecocokan)", n)
+}
 
-			usersInput[j+1] = key
-		}
+// terminalSupportsColor reports whether the terminal is expected to show ANSI colours.
+// Colours are turned off when NO_COLOR is set or TERM is empty or "dumb".
+func terminalSupportsColor() bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
 	}
 
-	return nil
+	term := os.Getenv("TERM")
+	return term != "" && term != "dumb"
 }
 
-// FindUserByUsername searches for a user with the specified username in the users array.
-// If found, it copies the user data to the provided user pointer.
-func FindUserByUsername(username string, user *User) error {
-	for i := 0; i < nUser; i++ {
-		if users[i].username == username {
-			*user = users[i]
-			return nil
+// normalizeWithMap lowercases a text and removes emoji modifiers like normalizeEmoji, and records
+// for every remaining rune its position in the original text, so matches found in the normalized
+// text can be mapped back.
+func normalizeWithMap(teks string, norm *[]rune, asal *[]int) {
+	*norm = nil
+	*asal = nil
+
+	runes := []rune(toLower(teks))
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == 0xFE0E || r == 0xFE0F || r == 0x200D || (r >= 0x1F3FB && r <= 0x1F3FF) {
+			continue
 		}
+		*norm = append(*norm, r)
+		*asal = append(*asal, i)
 	}
-	return fmt.Errorf("pengguna dengan username '%s' tidak ditemukan", username)
 }
 
-// FindUserById searches for a user with the specified ID using binary search algorithm.
-// It assumes that the global users array is sorted by ID in ascending order.
-// If found, it copies the user data to the provided user pointer.
-func FindUserById(userId int, user *User) error {
-	var left, right, mid int
-
-	left = 0
-	right = nUser - 1
+// findWordSpans records the [start, end) positions of the words in a lowercased text,
+// using the same definition of a word as tokenize.
+func findWordSpans(runes []rune, words *[NTOKEN][2]int, n *int) {
+	var start int = -1
 
-	for left <= right {
-		mid = (left + right) / 2
+	*n = 0
+	for i := 0; i <= len(runes); i++ {
+		isWordChar := i < len(runes) && isWordRune(runes[i])
 
-		if users[mid].id == userId {
-			*user = users[mid]
-			return nil
+		if isWordChar && start == -1 {
+			start = i
+		} else if !isWordChar && start != -1 {
+			addSpan(words, n, start, i)
+			start = -1
 		}
+	}
+}
 
-		if users[mid].id < userId {
-			left = mid + 1
-		} else {
-			right = mid - 1
-		}
+// addOccurrenceSpans adds a span for every non-overlapping occurrence of pola in teks.
+func addOccurrenceSpans(teks, pola []rune, spans *[NTOKEN][2]int, n *int) {
+	if len(pola) == 0 {
+		return
+	}
+
+	pos := indexRunes(teks, pola, 0)
+	for pos != -1 {
+		addSpan(spans, n, pos, pos+len(pola))
+		pos = indexRunes(teks, pola, pos+len(pola))
 	}
-	return fmt.Errorf("pengguna dengan ID %d tidak ditemukan", userId)
 }
 
-// CreateUser creates a new user with the specified username and password.
-// It adds the user to the users array and assigns a unique ID.
-func CreateUser(username, password string) error {
-	if nUser >= NMAX {
-		return fmt.Errorf("jumlah pengguna sudah mencapai batas maksimum")
+// addSpan appends a [start, end) span when there is room left.
+func addSpan(spans *[NTOKEN][2]int, n *int, start, end int) {
+	if *n < NTOKEN && end > start {
+		spans[*n] = [2]int{start, end}
+		*n++
 	}
+}
 
-	for i := 0; i < nUser; i++ {
-		if users[i].username == username {
-			return fmt.Errorf("username '%s' sudah terdaftar", username)
+// sortAndMergeSpans sorts spans by their start with insertion sort and merges spans that overlap or touch.
+func sortAndMergeSpans(spans *[NTOKEN][2]int, n *int) {
+	for i := 1; i < *n; i++ {
+		key := spans[i]
+		j := i - 1
+		for j >= 0 && spans[j][0] > key[0] {
+			spans[j+1] = spans[j]
+			j--
 		}
+		spans[j+1] = key
 	}
 
-	users[nUser] = User{
-		id:       idUser,
-		username: username,
-		password: password,
+	m := 0
+	for i := 0; i < *n; i++ {
+		if m > 0 && spans[i][0] <= spans[m-1][1] {
+			if spans[i][1] > spans[m-1][1] {
+				spans[m-1][1] = spans[i][1]
+			}
+			continue
+		}
+		spans[m] = spans[i]
+		m++
 	}
-	nUser++
-	idUser++
-	return nil
+	*n = m
 }
 
-// EditUser updates a user's username and/or password using binary search to find the user.
-// It assumes that the users array is sorted by ID in ascending order.
-func EditUser(username, password string, userId int) error {
-	var left, right, mid int
-
-	left = 0
-	right = nUser - 1
+// containsRunes reports whether pola occurs in teks, comparing rune by rune.
+func containsRunes(teks, pola []rune) bool {
+	return indexRunes(teks, pola, 0) != -1
+}
 
-	for left <= right {
-		mid = (left + right) / 2
+// indexRunes returns the position of the first occurrence of pola in teks at or after dari,
+// or -1 when there is none.
+func indexRunes(teks, pola []rune, dari int) int {
+	for j := dari; j <= len(teks)-len(pola); j++ {
+		isMatch := true
 
-		if users[mid].id == userId {
-			if username != "" {
-				users[mid].username = username
-			}
-			if password != "" {
-				users[mid].password = password
+		for k := 0; k < len(pola); k++ {
+			if teks[j+k] != pola[k] {
+				isMatch = false
+				break
 			}
-			return nil
 		}
 
-		if users[mid].id < userId {
-			left = mid + 1
-		} else {
-			right = mid - 1
+		if isMatch {
+			return j
 		}
 	}
-	return fmt.Errorf("pengguna dengan ID %d tidak ditemukan", userId)
-}
-
-// DeleteUser removes a user with the specified ID from the users array using binary search.
-// It assumes that the users array is sorted by ID in ascending order.
-// Once found, it deletes the user by shifting all subsequent elements one
-// position to the left to fill the gap, then decrements the user counter.
-func DeleteUser(userId int) error {
-	var left, right, mid int
-	left = 0
-	right = nUser - 1
 
-	for left <= right {
-		mid = (left + right) / 2
+	return -1
+}
 
-		if users[mid].id == userId {
-			for j := mid; j < nUser-1; j++ {
-				users[j] = users[j+1]
-			}
-			users[nUser-1] = User{}
-			nUser--
-			return nil
+// hasWildcard reports whether a search word contains * or ?.
+func hasWildcard(kata string) bool {
+	for i := 0; i < len(kata); i++ {
+		if kata[i] == '*' || kata[i] == '?' {
+			return true
 		}
+	}
+	return false
+}
 
-		if users[mid].id < userId {
-			left = mid + 1
+// matchWildcard reports whether the whole word matches the pattern, where * matches any run
+// of characters, including none, and ? matches exactly one character.
+// When a * fails to match, the search backtracks to the last * and lets it take one more character.
+func matchWildcard(kata, pola []rune) bool {
+	var i, j int
+	var bintang, cocok int = -1, 0
+
+	for i < len(kata) {
+		if j < len(pola) && (pola[j] == '?' || pola[j] == kata[i]) {
+			i++
+			j++
+		} else if j < len(pola) && pola[j] == '*' {
+			bintang = j
+			cocok = i
+			j++
+		} else if bintang != -1 {
+			j = bintang + 1
+			cocok++
+			i = cocok
 		} else {
-			right = mid - 1
+			return false
 		}
 	}
-	return fmt.Errorf("pengguna dengan ID %d tidak ditemukan", userId)
-}
 
-// CreateComment adds a new comment to the system with the specified content and category.
-// It assigns a unique ID to the comment and associates it with the given user.
-func CreateComment(user User, komentar, kategori string) error {
-	if nComment >= NMAX {
-		return fmt.Errorf("jumlah komentar sudah mencapai batas maksimum")
+	for j < len(pola) && pola[j] == '*' {
+		j++
 	}
 
-	comments[nComment] = Comment{
-		id:       idComment,
-		userId:   user.id,
-		komentar: komentar,
-		kategori: kategori,
-	}
-	nComment++
-	idComment++
-	return nil
+	return j == len(pola)
 }
 
-// CountCommentsByCategory counts the number of comments that match the specified category.
-// It iterates through all comments in the global comments array and increments a counter
-// each time it finds a comment with a matching kategori field.
-func CountCommentsByCategory(category string) int {
-	var count int
+// parseNumber converts a string of decimal digits to an int.
+// It reports false when the string is empty or contains anything other than digits.
+func parseNumber(s string) (int, bool) {
+	var n int
 
-	for i := 0; i < nComment; i++ {
-		if comments[i].kategori == category {
-			count++
+	if s == "" {
+		return 0, false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, false
 		}
+		n = n*10 + int(s[i]-'0')
 	}
 
-	return count
+	return n, true
 }
 
-// GetComments retrieves all available comments from the system and copies them to the provided array.
-func GetComments(commentsInput *[NMAX]Comment) error {
-	if nComment == 0 {
-		return fmt.Errorf("tidak ada komentar yang tersedia")
+// lowerRune converts an uppercase ASCII or Latin-1 letter to lowercase, like toLower.
+func lowerRune(r rune) rune {
+	if (r >= 'A' && r <= 'Z') || (r >= 0xC0 && r <= 0xDE && r != 0xD7) {
+		return r + 32
 	}
+	return r
+}
 
-	*commentsInput = comments
+// upperRune converts a lowercase ASCII or Latin-1 letter to uppercase.
+func upperRune(r rune) rune {
+	if (r >= 'a' && r <= 'z') || (r >= 0xE0 && r <= 0xFE && r != 0xF7) {
+		return r - 32
+	}
+	return r
+}
 
-	return nil
+// isSpaceRune reports whether r is a space, tab, or line break.
+func isSpaceRune(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r'
 }
 
-// GetCommentsSearch searches through all comments for those containing the specified search string.
-// It performs a case-insensitive substring search by converting both the search term and
-// comment text to lowercase before comparison.
-func GetCommentsSearch(commentsInput *[NMAX]Comment, search string) error {
-	var matchCount int
-	var isMatch bool
+// isWordRune reports whether a rune is part of a word: an ASCII letter or digit,
+// or a non-ASCII letter such as an accented Latin character.
+func isWordRune(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || (r >= 0xC0 && r < 0x2000 && r != 0xD7 && r != 0xF7)
+}
 
-	if nComment == 0 {
-		return fmt.Errorf("tidak ada komentar yang tersedia")
-	}
+// isEmojiRune reports whether a rune lies in one of the Unicode blocks used for emoji.
+func isEmojiRune(r rune) bool {
+	return (r >= 0x1F000 && r <= 0x1FAFF) || (r >= 0x2600 && r <= 0x27BF) || (r >= 0x2B00 && r <= 0x2BFF)
+}
 
-	var tempComments [NMAX]Comment
-	matchCount = 0
+// normalizeEmoji removes the variation selectors, skin tone modifiers, and zero-width joiners
+// that decorate emoji, so that for example "❤️" and "👍🏽" match "❤" and "👍".
+func normalizeEmoji(s string) string {
+	var result []rune
 
-	search = toLower(search)
+	for _, r := range s {
+		if r == 0xFE0E || r == 0xFE0F || r == 0x200D || (r >= 0x1F3FB && r <= 0x1F3FF) {
+			continue
+		}
+		result = append(result, r)
+	}
 
-	for i := 0; i < nComment; i++ {
-		commentLower := toLower(comments[i].komentar)
-		isMatch = false
+	return string(result)
+}
 
-		for j := 0; j <= len(commentLower)-len(search); j++ {
-			isMatch = true
+// userState returns the effective account state of a user. A suspension whose end date has
+// passed is reported as "active".
+func userState(user User) string {
+	if user.status == "" || (user.status == "suspended" && !time.Now().Before(user.ditangguhkan)) {
+		return "active"
+	}
+	return user.status
+}
 
-			for k := 0; k < len(search); k++ {
-				if commentLower[j+k] != search[k] {
-					isMatch = false
-					break
-				}
-			}
+// userStatusLabel formats the account state of a user for listings.
+func userStatusLabel(user User) string {
+	switch userState(user) {
+	case "suspended":
+		return fmt.Sprintf("suspended hingga %s (%s)", user.ditangguhkan.Format("02-01-2006 15:04"), user.alasanSanksi)
+	case "banned":
+		return fmt.Sprintf("banned (%s)", user.alasanSanksi)
+	}
+	return "active"
+}
 
-			if isMatch {
-				tempComments[matchCount] = comments[i]
-				matchCount++
-				break
-			}
+// statusIndex returns the position of a moderation status in statusList, or -1 if it is not a valid status.
+func statusIndex(status string) int {
+	for i := 0; i < 4; i++ {
+		if statusList[i] == status {
+			return i
 		}
 	}
+	return -1
+}
 
-	if matchCount == 0 {
-		return fmt.Errorf("tidak ada komentar yang sesuai dengan pencarian")
+// kategoriIndex returns the position of a category in kategoriList, or -1 if it is not a valid category.
+func kategoriIndex(kategori string) int {
+	for i := 0; i < 3; i++ {
+		if kategoriList[i] == kategori {
+			return i
+		}
+	}
+	return -1
+}
+
+// effectiveKategori returns the category confirmed by a moderator, or the predicted category
+// when the comment has not been confirmed yet.
+func effectiveKategori(comment Comment) string {
+	if comment.kategoriKonfirmasi != "" {
+		return comment.kategoriKonfirmasi
+	}
+	return comment.kategori
+}
+
+// kategoriLabel formats the category of a comment for listings, showing either that it was
+// confirmed by a moderator or the confidence of the prediction.
+func kategoriLabel(comment Comment) string {
+	if comment.kategoriKonfirmasi != "" {
+		return fmt.Sprintf("%s (dikonfirmasi)", comment.kategoriKonfirmasi)
 	}
+	return fmt.Sprintf("%s (keyakinan %.2f)", comment.kategori, comment.keyakinan)
+}
 
-	for i := 0; i < NMAX; i++ {
-		if i < matchCount {
-			commentsInput[i] = tempComments[i]
+// aspekLabel formats the per-aspect sentiment of a comment for listings,
+// for example ", Aspek: harga=negatif, pengiriman=positif". It is empty when no aspect is mentioned.
+func aspekLabel(comment Comment) string {
+	var label string
+	var aspect Aspect
+
+	for a := 0; a < comment.nAspek; a++ {
+		if err := FindAspectById(comment.aspek[a].aspekId, &aspect); err != nil {
+			continue
+		}
+
+		if label == "" {
+			label = ", Aspek: "
 		} else {
-			commentsInput[i] = Comment{}
+			label += ", "
 		}
+		label += aspect.nama + "=" + comment.aspek[a].kategori
 	}
 
-	return nil
+	return label
 }
 
-// GetCommentsSort sorts the comments array by ID and stores the result in the provided commentsInput.
-// It prompts the user to choose between ascending or descending sort order through a menu interface.
-// Selection sort is used for ascending order, and insertion sort is used for descending order.
-func GetCommentsSort(commentsInput *[NMAX]Comment) error {
-	var input int
-	var key Comment
+// isVisibleComment reports whether the entry at index i of the listing is filled and matches the filter.
+func isVisibleComment(commentsData *[NMAX]Comment, i int, filter CommentFilter) bool {
+	return commentsData[i].id != 0 && MatchCommentFilter(commentsData[i], filter)
+}
 
-	if nComment == 0 {
-		return fmt.Errorf("tidak ada komentar yang tersedia")
+// isThreadRoot reports whether the entry at index i should start a thread in the listing.
+// A visible comment starts a thread when it is not a reply or its parent is not part of the listing,
+// for example because the parent was filtered out by a search.
+func isThreadRoot(commentsData *[NMAX]Comment, i int, filter CommentFilter) bool {
+	if !isVisibleComment(commentsData, i, filter) {
+		return false
 	}
 
-	err := PrintMenu("Pilih Urutan", [255]string{"Ascending (A-Z)", "Descending (Z-A)"}, 2, &input)
-	if err != nil {
-		return err
+	if commentsData[i].parentId == 0 {
+		return true
 	}
 
-	*commentsInput = comments
+	for j := 0; j < nComment; j++ {
+		if commentsData[j].id == commentsData[i].parentId && isVisibleComment(commentsData, j, filter) {
+			return false
+		}
+	}
 
-	if input == 1 {
-		for i := 0; i < nComment-1; i++ {
-			minIdx := i
-			for j := i + 1; j < nComment; j++ {
-				if commentsInput[j].id < commentsInput[minIdx].id {
-					minIdx = j
-				}
-			}
+	return true
+}
 
-			commentsInput[i], commentsInput[minIdx] = commentsInput[minIdx], commentsInput[i]
-		}
-	} else {
-		for i := 1; i < nComment; i++ {
-			key = commentsInput[i]
-			j := i - 1
+// printCommentThread prints the entry at index i and, below it, its visible replies indented one level deeper.
+// Replies are printed in the order of the listing, so a sorted listing keeps its order within each thread.
+// Entries outside the page are counted but not printed; a nil page prints every entry.
+func printCommentThread(commentsData *[NMAX]Comment, i, depth int, filter CommentFilter, pencarian *SearchMatcher, page *Pagination, n *int) {
+	var indent string
+	for d := 0; d < depth; d++ {
+		indent += "    "
+	}
+	if depth > 0 {
+		indent += "> "
+	}
 
-			for j >= 0 && commentsInput[j].id < key.id {
-				commentsInput[j+1] = commentsInput[j]
-				j--
-			}
+	printCommentEntry(commentsData[i], indent, false, pencarian, page, n)
 
-			commentsInput[j+1] = key
+	for j := 0; j < nComment; j++ {
+		if commentsData[j].parentId == commentsData[i].id && isVisibleComment(commentsData, j, filter) {
+			printCommentThread(commentsData, j, depth+1, filter, pencarian, page, n)
 		}
 	}
-
-	return nil
 }
 
-// FindCommentById searches for a comment with the specified ID using binary search.
-// It assumes that the comments array is sorted by ID in ascending order.
-// If found, it copies the comment data to the provided comment pointer.
-func FindCommentById(id int, comment *Comment) error {
-	var left, right, mid int
+// printCommentEntry prints one numbered entry of the comment listing when it falls inside the page,
+// then advances the number. When a search is active its matches are highlighted and long comments
+// are shortened around them. With showParent a reply is marked with the ID of the comment it replies to,
+// for flat listings that do not show threads.
+func printCommentEntry(comment Comment, indent string, showParent bool, pencarian *SearchMatcher, page *Pagination, n *int) {
+	var spans [NTOKEN][2]int
+	var nSpan int
+	var balasan string
+
+	if isInPage(page, *n) {
+		komentar := displayKomentar(comment)
+		if pencarian != nil {
+			FindMatchSpans(pencarian, comment.komentar, &spans, &nSpan)
+			komentar = FormatSearchResult(komentar, &spans, nSpan)
+		}
+		if showParent && comment.parentId != 0 {
+			balasan = fmt.Sprintf(" [Balasan untuk ID %d]", comment.parentId)
+		}
 
-	left = 0
-	right = nComment - 1
+		fmt.Printf("%s%d. ID: %d, Penulis: %s, Komentar: %s, Kategori: %s%s%s%s%s%s%s\n", indent, *n, comment.id, authorLabel(comment), komentar, kategoriLabel(comment), aspekLabel(comment), flagLabel(comment), threadLabel(comment), balasan, sumberLabel(comment.sumber), matchCountLabel(pencarian, nSpan))
+	}
+	*n++
+}
 
-	for left <= right {
-		mid = (left + right) / 2
+// authorName returns the name of the author of a comment and whether it is known. Comments without
+// a user account, such as imported ones, are attributed to the handle of their source when it has one.
+func authorName(comment Comment) (string, bool) {
+	var user User
 
-		if comments[mid].id == id {
-			*comment = comments[mid]
-			return nil
-		}
+	if comment.userId == 0 {
+		return comment.sumber.handle, comment.sumber.handle != ""
+	}
+	if err := FindUserById(comment.userId, &user); err != nil {
+		return "", false
+	}
+	return user.username, true
+}
 
-		if comments[mid].id < id {
-			left = mid + 1
-		} else {
-			right = mid - 1
+// authorLabel returns the author shown in comment listings. Imported comments are marked with
+// their source handle, and authors whose account was deleted are marked with their former ID
+// when it is still known.
+func authorLabel(comment Comment) string {
+	nama, ada := authorName(comment)
+	if comment.userId == 0 {
+		if ada {
+			return "@" + nama + " (impor)"
+		}
+		if comment.sumber != (CommentSource{}) {
+			return "(impor)"
 		}
+		return "(tanpa akun)"
+	}
+	if ada {
+		return nama
+	}
+	if comment.userId == userDihapusId {
+		return "(user terhapus)"
 	}
+	return fmt.Sprintf("(user terhapus, ID %d)", comment.userId)
+}
 
-	return fmt.Errorf("komentar dengan ID %d tidak ditemukan", id)
+// sumberLabel returns the source shown next to a comment in listings, or an empty string
+// for comments written in the application.
+func sumberLabel(sumber CommentSource) string {
+	if sumber == (CommentSource{}) {
+		return ""
+	}
+	return " [" + sourceLabel(sumber) + "]"
 }
 
-// EditComment updates an existing comment's text and/or category with the provided values.
-// It searches for a comment with the specified ID in the global comments array.
-func EditComment(komen, kategori string, id int) error {
-	var left, right, mid int
+// nextRandom advances a linear congruential generator and returns a non-negative pseudo-random number.
+func nextRandom(seed *uint32) int {
+	*seed = *seed*1664525 + 1013904223
+	return int(*seed >> 1)
+}
 
-	left = 0
-	right = nComment - 1
+// compareText compares two strings byte by byte and returns -1, 0 or 1.
+func compareText(a, b string) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
 
-	for left <= right {
-		mid = (left + right) / 2
+// sortStatsLabel describes a finished sort with the number of comparisons and swaps or shifts.
+func sortStatsLabel(spec SortSpec, stats SortStats) string {
+	var label string
 
-		if comments[mid].id == id {
-			if komen != "" {
-				comments[mid].komentar = komen
-			}
-			if kategori != "" {
-				comments[mid].kategori = kategori
-			}
-			return nil
+	for k := 0; k < spec.nKunci; k++ {
+		if k > 0 {
+			label += ", "
 		}
-
-		if comments[mid].id < id {
-			left = mid + 1
+		label += spec.kunci[k]
+		if spec.menurun[k] {
+			label += " (desc)"
 		} else {
-			right = mid - 1
+			label += " (asc)"
 		}
 	}
 
-	return fmt.Errorf("komentar dengan ID %d tidak ditemukan", id)
+	if spec.algoritma == "insertion" {
+		return fmt.Sprintf("Diurutkan berdasarkan %s dengan insertion sort: %d perbandingan, %d pergeseran", label, stats.perbandingan, stats.pertukaran)
+	}
+	return fmt.Sprintf("Diurutkan berdasarkan %s dengan selection sort: %d perbandingan, %d pertukaran", label, stats.perbandingan, stats.pertukaran)
 }
 
-// DeleteComment removes a comment with the specified ID from the comments array using binary search.
-// It assumes that the comments array is sorted by ID in ascending order.
-// Once found, it deletes the comment by shifting all subsequent elements one
-// position to the left to fill the gap, then decrements the comment counter.
-func DeleteComment(id int) error {
-	var left, right, mid int
+// pageCount returns the number of pages needed for total entries. An empty listing has one page.
+func pageCount(total, ukuran int) int {
+	if total == 0 {
+		return 1
+	}
+	return (total + ukuran - 1) / ukuran
+}
 
-	left = 0
-	right = nComment - 1
+// isInPage reports whether the entry with the 1-based number n is shown on the current page.
+// A nil page shows every entry.
+func isInPage(page *Pagination, n int) bool {
+	if page == nil {
+		return true
+	}
+	return n > (page.halaman-1)*page.ukuran && n <= page.halaman*page.ukuran
+}
 
-	for left <= right {
-		mid = (left + right) / 2
+// pageLabel returns the page position and the total number of entries shown below a listing.
+func pageLabel(page Pagination, total int, satuan string) string {
+	return fmt.Sprintf("Halaman %d dari %d, total %d %s", page.halaman, pageCount(total, page.ukuran), total, satuan)
+}
 
-		if comments[mid].id == id {
-			for j := mid; j < nComment-1; j++ {
-				comments[j] = comments[j+1]
-			}
-			comments[nComment-1] = Comment{}
-			nComment--
-			return nil
+// filterLabel describes the active conditions of a filter other than the moderation status,
+// or returns an empty string when there are none.
+func filterLabel(filter CommentFilter) string {
+	var label string
+
+	add := func(part string) {
+		if label != "" {
+			label += ", "
 		}
+		label += part
+	}
 
-		if comments[mid].id < id {
-			left = mid + 1
+	if filter.kategori != "" {
+		add("kategori " + filter.kategori)
+	}
+	if filter.penulisId != 0 {
+		var user User
+
+		if err := FindUserById(filter.penulisId, &user); err != nil {
+			add(fmt.Sprintf("penulis ID %d", filter.penulisId))
 		} else {
-			right = mid - 1
+			add("penulis " + user.username)
+		}
+	}
+	if !filter.dari.IsZero() || !filter.sampai.IsZero() {
+		dari, sampai := "...", "..."
+		if !filter.dari.IsZero() {
+			dari = filter.dari.Format("02-01-2006")
+		}
+		if !filter.sampai.IsZero() {
+			sampai = filter.sampai.Format("02-01-2006")
 		}
+		add("tanggal " + dari + " s.d. " + sampai)
+	}
+	if filter.panjangMin > 0 || filter.panjangMax > 0 {
+		if filter.panjangMin == 0 {
+			add(fmt.Sprintf("panjang <= %d", filter.panjangMax))
+		} else if filter.panjangMax > 0 {
+			add(fmt.Sprintf("panjang %d-%d", filter.panjangMin, filter.panjangMax))
+		} else {
+			add(fmt.Sprintf("panjang >= %d", filter.panjangMin))
+		}
+	}
+	for i := 0; i < 4; i++ {
+		if filter.tanda[i] {
+			add(tandaList[i])
+		}
+	}
+	if filter.sumber != (CommentSource{}) {
+		add("sumber " + sourceLabel(filter.sumber))
 	}
 
-	return fmt.Errorf("komentar dengan ID %d tidak ditemukan", id)
+	return label
 }
 
-// Helper
-
-// PrintTitle formats and displays the given title text within a bordered box.
-// If the title is longer than the predefined width (38 characters), it will be
-// split into multiple lines, breaking at spaces when possible.
-//
-// The function centers each line of text and adds decorative borders
-// around the entire title.
-func PrintTitle(title string) {
-	const width int = 38
-	var start, currentPos, lastSpace int
+// sourceLabel formats the filled fields of a source as platform/post#id @handle.
+func sourceLabel(sumber CommentSource) string {
+	var label string
 
-	if len(title) <= width {
-		printBorder()
-		printCenteredText(title, width)
-		printBorder()
-		return
+	label = sumber.platform
+	if sumber.postId != "" {
+		label += "/" + sumber.postId
+	}
+	if sumber.idEksternal != "" {
+		label += "#" + sumber.idEksternal
+	}
+	if sumber.handle != "" {
+		if label != "" {
+			label += " "
+		}
+		label += "@" + sumber.handle
 	}
 
-	printBorder()
+	return label
+}
 
-	start = 0
-	currentPos = 0
-	lastSpace = -1
+// threadLabel returns the thread sentiment shown next to a top-level comment that has replies.
+func threadLabel(comment Comment) string {
+	var thread ThreadSentiment
 
-	for currentPos < len(title) {
-		if currentPos-start >= width {
-			if lastSpace > start {
-				printCenteredText(title[start:lastSpace], width)
-				start = lastSpace + 1
-				currentPos = start
-				lastSpace = -1
-			} else {
-				printCenteredText(title[start:currentPos], width)
-				start = currentPos
-			}
-		} else if title[currentPos] == ' ' {
-			lastSpace = currentPos
-			currentPos++
-		} else {
-			currentPos++
-		}
+	if comment.parentId != 0 {
+		return ""
 	}
 
-	if start < len(title) {
-		printCenteredText(title[start:], width)
+	if err := AnalyzeThreadSentiment(comment.id, &thread); err != nil || thread.jumlah <= 1 {
+		return ""
 	}
 
-	printBorder()
+	return fmt.Sprintf(" [Thread: %d balasan, sentimen %s %.2f]", thread.jumlah-1, thread.kategori, thread.skor)
 }
 
-// printBorder prints a horizontal border consisting of 42 equal signs.
-// Used to create the top and bottom borders of the title box.
-func printBorder() {
-	fmt.Println("==========================================")
+// displayKomentar returns the comment text as it should appear in listings,
+// with abusive words masked when the sensor is active.
+func displayKomentar(comment Comment) string {
+	if sensorAktif && comment.skorToksisitas > 0 {
+		return MaskProfanity(comment.komentar)
+	}
+	return comment.komentar
 }
 
-// printCenteredText formats and prints a single line of text, centered within
-// the specified width. The text is surrounded by "= " on the left and " =" on the right.
-//
-// For odd-length text, an extra space is added to the right padding to maintain
-// proper centering and border alignment.
-func printCenteredText(text string, width int) {
-	var leftPadding, rightPadding int
-
-	leftPadding = (width - len(text)) / 2
-	rightPadding = (width - len(text)) / 2
+// flagLabel returns the markers shown in listings for comments flagged as toxic or duplicate,
+// and the number of duplicates merged into the comment.
+func flagLabel(comment Comment) string {
+	var label string
 
-	if len(text)%2 != 0 {
-		rightPadding++
+	if comment.toksik {
+		label += fmt.Sprintf(" [TOKSIK %.2f]", comment.skorToksisitas)
 	}
-
-	fmt.Print("= ")
-	for i := 0; i < leftPadding; i++ {
-		fmt.Print(" ")
+	if comment.duplikatDari != 0 {
+		label += fmt.Sprintf(" [DUPLIKAT #%d]", comment.duplikatDari)
 	}
-	fmt.Print(text)
-	for i := 0; i < rightPadding; i++ {
-		fmt.Print(" ")
+	if comment.jumlahGabungan > 0 {
+		label += fmt.Sprintf(" (+%d digabung)", comment.jumlahGabungan)
 	}
-	fmt.Println(" =")
+	if comment.status != "approved" {
+		label += fmt.Sprintf(" [%s]", comment.status)
+	}
+
+	return label
 }
 
-// PrintMenu displays a menu of options and captures the user's selection.
-// The function first displays all menu options as a numbered list. It then enters a loop
-// that prompts the user for input until a valid selection is made. If the user enters
-// an invalid option (outside the range 1-n), an error message is displayed and the user
-// is prompted again. This continues until a valid selection is made or an input error occurs.
-func PrintMenu(menuTitle string, menu [255]string, n int, answer *int) error {
-	for i := 0; i < n; i++ {
-		fmt.Printf("%d. %s\n", i+1, menu[i])
+// countSentimentWords counts the filled entries of a sentiment word array.
+func countSentimentWords(words [NMAX]SentimentWord) int {
+	var n int
+	for n < NMAX && words[n].kata != "" {
+		n++
 	}
+	return n
+}
 
-	for {
-		var input int
-		fmt.Printf("%s (1-%d): ", menuTitle, n)
-		_, err := fmt.Scan(&input)
+// safeDivide divides a by b, returning 0 when b is zero.
+func safeDivide(a, b float64) float64 {
+	if b == 0 {
+		return 0
+	}
+	return a / b
+}
 
-		if err != nil {
-			fmt.Print(err.Error())
-			continue
-		}
+// PrintEvaluationReport displays an evaluation report as a confusion matrix followed by
+// per-category precision, recall, and F1 score, the macro averages, and the fold accuracies.
+func PrintEvaluationReport(report EvaluationReport) {
+	fmt.Printf("\nModel: %s (%d komentar berlabel)\n", report.model, report.total)
+	fmt.Printf("%-16s%10s%10s%10s\n", "Aktual\\Prediksi", kategoriList[0], kategoriList[1], kategoriList[2])
+	for a := 0; a < 3; a++ {
+		fmt.Printf("%-16s%10d%10d%10d\n", kategoriList[a], report.matrix[a][0], report.matrix[a][1], report.matrix[a][2])
+	}
 
-		if input >= 1 && input <= n {
-			*answer = input
-			return nil
-		}
+	fmt.Printf("\n%-16s%10s%10s%10s\n", "Kategori", "Precision", "Recall", "F1")
+	for k := 0; k < 3; k++ {
+		fmt.Printf("%-16s%10.2f%10.2f%10.2f\n", kategoriList[k], report.precision[k], report.recall[k], report.f1[k])
+	}
+	fmt.Printf("%-16s%10.2f%10.2f%10.2f\n", "Macro", report.macroPrecision, report.macroRecall, report.macroF1)
+	fmt.Printf("Akurasi: %.2f\n", report.accuracy)
 
-		fmt.Printf("Pilihan tidak valid, silakan pilih antara 1 dan %d\n", n)
+	for f := 0; f < report.folds; f++ {
+		fmt.Printf("Akurasi fold %d: %.2f\n", f+1, report.foldAccuracy[f])
 	}
 }
 
-// PrintBreadcrumbs displays a hierarchical navigation path starting with "Main Menu".
-// It prints the first n elements from the links array, separated by " > " characters.
-// The last element is printed without a trailing separator.
-func PrintBreadcrumbs(links [255]string, n int) {
-	fmt.Print("Main Menu > ")
-	for i := 0; i < n; i++ {
-		if i == n-1 {
-			fmt.Print(links[i])
-		} else {
-			fmt.Print(links[i] + " > ")
+// ReadLine prints the prompt and reads a whole line from standard input, so that the answer
+// may contain spaces. Leading blank lines left over from earlier input are skipped and
+// surrounding whitespace is removed.
+func ReadLine(prompt string, line *string) error {
+	var buf [1]byte
+	var result []byte
+
+	fmt.Print(prompt)
+
+	for {
+		n, err := os.Stdin.Read(buf[:])
+		if n == 0 || err != nil {
+			if len(result) > 0 {
+				break
+			}
+			if err == nil {
+				err = fmt.Errorf("input kosong")
+			}
+			return err
 		}
-	}
-	fmt.Println()
-}
 
-// toLower converts a string to lowercase by changing any uppercase ASCII characters
-// (A-Z) to their lowercase equivalents.
-func toLower(s string) string {
-	for i := 0; i < len(s); i++ {
-		if s[i] >= 'A' && s[i] <= 'Z' {
-			s = s[:i] + string(s[i]+32) + s[i+1:]
+		if buf[0] == '\n' {
+			if len(result) > 0 {
+				break
+			}
+			continue
+		}
+
+		if len(result) == 0 && (buf[0] == ' ' || buf[0] == '\t' || buf[0] == '\r') {
+			continue
 		}
+		result = append(result, buf[0])
+	}
+
+	for len(result) > 0 && (result[len(result)-1] == ' ' || result[len(result)-1] == '\t' || result[len(result)-1] == '\r') {
+		result = result[:len(result)-1]
 	}
 
-	return s
+	*line = string(result)
+	return nil
 }